@@ -0,0 +1,220 @@
+package main
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Fixed point number, its value is Unscaled * 10^-Scale
+type Decimal struct {
+	Unscaled *big.Int
+	Scale    int
+}
+
+var bigTen = big.NewInt(10)
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// Parses decimal notation like "-12.50", amount of digits after the dot becomes the scale
+func ParseDecimal(str string) (*Decimal, bool) {
+	intPart, fracPart, _ := strings.Cut(str, ".")
+	if intPart == "" || intPart == "-" || intPart == "+" {
+		intPart += "0"
+	}
+
+	unscaled, ok := new(big.Int).SetString(intPart+fracPart, 10)
+	if !ok || strings.ContainsAny(fracPart, "+-") {
+		return nil, false
+	}
+
+	return &Decimal{unscaled, len(fracPart)}, true
+}
+
+// Returns the same value with another scale, extra digits are rounded half away from zero
+func (d *Decimal) Rescale(scale int) *Decimal {
+	if scale >= d.Scale {
+		return &Decimal{new(big.Int).Mul(d.Unscaled, pow10(scale-d.Scale)), scale}
+	}
+
+	return &Decimal{divRound(d.Unscaled, pow10(d.Scale-scale)), scale}
+}
+
+// Integer part of the decimal
+func (d *Decimal) Int() *big.Int {
+	return new(big.Int).Quo(d.Unscaled, pow10(d.Scale))
+}
+
+func (d *Decimal) Cmp(other *Decimal) int {
+	scale := max(d.Scale, other.Scale)
+
+	return d.Rescale(scale).Unscaled.Cmp(other.Rescale(scale).Unscaled)
+}
+
+func (d *Decimal) String() string {
+	digits := new(big.Int).Abs(d.Unscaled).String()
+	if d.Scale > 0 {
+		if len(digits) <= d.Scale {
+			digits = strings.Repeat("0", d.Scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-d.Scale] + "." + digits[len(digits)-d.Scale:]
+	}
+
+	if d.Unscaled.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// Divides a by b rounding the result half away from zero
+func divRound(a, b *big.Int) *big.Int {
+	quo, rem := new(big.Int).QuoRem(a, b, new(big.Int))
+
+	twiceRem := new(big.Int).Abs(rem)
+	if twiceRem.Lsh(twiceRem, 1).CmpAbs(b) >= 0 {
+		if a.Sign()*b.Sign() < 0 {
+			quo.Sub(quo, big.NewInt(1))
+		} else {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+
+	return quo
+}
+
+func isBigNumber(v any) bool {
+	switch v.(type) {
+	case *big.Int, *Decimal:
+		return true
+	}
+
+	return false
+}
+
+// Converts number or string value to a bigint or decimal
+func toBigNumber(v any, dataType string) (any, bool) {
+	var integer *big.Int
+
+	switch v := v.(type) {
+	case *big.Int:
+		integer = v
+	case *Decimal:
+		if dataType == "decimal" {
+			return v, true
+		}
+		integer = v.Int()
+	case string:
+		if dataType == "decimal" {
+			return ParseDecimal(v)
+		}
+
+		return new(big.Int).SetString(v, 0)
+	case float64, float32:
+		f := mustNTOF64(v)
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, false
+		}
+		if dataType == "decimal" {
+			return ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+		}
+
+		integer, _ = big.NewFloat(f).Int(nil)
+	case uint64, uint32, uint16, uint8, rawuint64:
+		integer = new(big.Int).SetUint64(toUint64(v))
+	case int64, int32, int16, int8, rawint64:
+		integer = big.NewInt(toInt64(v))
+	default:
+		return nil, false
+	}
+
+	if dataType == "decimal" {
+		return &Decimal{integer, 0}, true
+	}
+	return integer, true
+}
+
+// Integer literals assigned to bigint and decimal cells become values of these types
+func adaptBigLiteral(value any, dataType string) any {
+	switch value.(type) {
+	case rawint64, rawuint64:
+		value, _ = toBigNumber(value, dataType)
+	}
+
+	return value
+}
+
+// Integer operands are converted to the type of the big operand, decimal wins over bigint
+func promoteBigOperands(a, b any) (any, any) {
+	dataType := "bigint"
+	if checkType[*Decimal](a) || checkType[*Decimal](b) {
+		dataType = "decimal"
+	}
+
+	convert := func(v any) any {
+		if !isBigNumber(v) && !checkDataType("int", v) && !checkType[rawuint64](v) {
+			return v
+		}
+
+		converted, _ := toBigNumber(v, dataType)
+		return converted
+	}
+
+	return convert(a), convert(b)
+}
+
+func bigCompare(a, b any) int {
+	if a, ok := a.(*Decimal); ok {
+		return a.Cmp(b.(*Decimal))
+	}
+
+	return a.(*big.Int).Cmp(b.(*big.Int))
+}
+
+// Performs arithmetic operation on two bigints or two decimals, result of decimals keeps the bigger scale
+func (inter *Interpreter) bigOperation(operation string, a, b any, x, y int) any {
+	if a, ok := a.(*big.Int); ok {
+		b := b.(*big.Int)
+
+		switch operation {
+		case "add":
+			return new(big.Int).Add(a, b)
+		case "sub":
+			return new(big.Int).Sub(a, b)
+		case "mul":
+			return new(big.Int).Mul(a, b)
+		}
+
+		if b.Sign() == 0 {
+			inter.throw("Integer division by zero.", x, y)
+		}
+		if operation == "mod" {
+			return new(big.Int).Rem(a, b)
+		}
+		return new(big.Int).Quo(a, b)
+	}
+
+	ad, bd := a.(*Decimal), b.(*Decimal)
+	scale := max(ad.Scale, bd.Scale)
+
+	switch operation {
+	case "add":
+		return &Decimal{new(big.Int).Add(ad.Rescale(scale).Unscaled, bd.Rescale(scale).Unscaled), scale}
+	case "sub":
+		return &Decimal{new(big.Int).Sub(ad.Rescale(scale).Unscaled, bd.Rescale(scale).Unscaled), scale}
+	case "mul":
+		return (&Decimal{new(big.Int).Mul(ad.Unscaled, bd.Unscaled), ad.Scale + bd.Scale}).Rescale(scale)
+	}
+
+	if bd.Unscaled.Sign() == 0 {
+		inter.throw("Decimal division by zero.", x, y)
+	}
+	if operation == "mod" {
+		return &Decimal{new(big.Int).Rem(ad.Rescale(scale).Unscaled, bd.Rescale(scale).Unscaled), scale}
+	}
+
+	dividend := new(big.Int).Mul(ad.Unscaled, pow10(scale+bd.Scale-ad.Scale))
+	return &Decimal{divRound(dividend, bd.Unscaled), scale}
+}
@@ -0,0 +1,464 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+)
+
+// Field of a binary format, count repeats the value, but is the byte length for 's' and the prefix width for 'p'
+type BinaryField struct {
+	Code  byte
+	Count int
+}
+
+type byteOrder interface {
+	binary.ByteOrder
+	binary.AppendByteOrder
+}
+
+var (
+	binaryByteOrders = map[byte]byteOrder{
+		'<': binary.LittleEndian,
+		'>': binary.BigEndian,
+		'!': binary.BigEndian,
+		'=': binary.NativeEndian,
+	}
+
+	//Sizes of the fixed-width codes, 's' is sized by its count and 'p' and 'z' by their values
+	binaryFieldSizes = map[byte]int{
+		'x': 1, '?': 1,
+		'b': 1, 'B': 1,
+		'h': 2, 'H': 2,
+		'i': 4, 'I': 4,
+		'q': 8, 'Q': 8,
+		'f': 4, 'd': 8,
+	}
+
+	errShortBinaryData = errors.New("binary data is too short for the format")
+)
+
+var (
+	binaryFuncs = map[string]func(v ...any) []any{
+		"binary_pack": func(v ...any) []any {
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			if len(v) == 0 {
+				inter.throw("Function requires one or more arguments.", x, y)
+			}
+			format, ok := v[0].(string)
+			if !ok {
+				inter.throw("Invalid argument #%d. Expected %s.", x, y, 1, "string")
+			}
+
+			packed, err := inter.packBinary(nil, format, v[1:], 2, x, y)
+			if err != nil {
+				return []any{newMap("u8", 0), err}
+			}
+
+			return []any{inter.bytesTable(packed, x, y), nil}
+		},
+
+		"binary_append": func(v ...any) []any {
+			argsCheck(v, 3, 3, "table", "string", "table")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			table := v[0].(*Map)
+			if table.DataType != "u8" {
+				inter.throw("Invalid argument #%d. Expected %s.", x, y, 1, "u8 table")
+			}
+
+			values := make([]any, 0, v[2].(*Map).Len())
+			for _, cell := range tableCells(v[2].(*Map)) {
+				values = append(values, cell.Get())
+			}
+
+			//Nothing is appended if the values don't fit the format
+			packed, err := inter.packBinary(nil, v[1].(string), values, 1, x, y)
+			if err != nil {
+				return []any{err}
+			}
+
+			table.mutex.Lock()
+			defer table.mutex.Unlock()
+
+			for _, b := range packed {
+				table.Set(int64(table.Len()), CLPTR(inter.CurrentScope, table.DataType, b, x, y))
+			}
+			table.toMemory()
+
+			return []any{nil}
+		},
+
+		"binary_unpack": func(v ...any) []any {
+			argsCheck(v, 3, 3, "string", "any", "int")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			data := inter.byteData(v[1], 2, x, y)
+			offset := toInt64(v[2])
+
+			values, next, err := inter.unpackBinary(v[0].(string), data, offset, x, y)
+			if err != nil {
+				return []any{newMap("any", 0), offset, err}
+			}
+
+			table := newMap("any", len(values))
+			for i, value := range values {
+				table.Set(int64(i), CLPTR(inter.CurrentScope, table.DataType, value, x, y))
+			}
+			table.ToMemory()
+
+			return []any{table, next, nil}
+		},
+
+		"binary_skip": func(v ...any) []any {
+			argsCheck(v, 3, 3, "table", "int", "int")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			table := v[0].(*Map)
+			table.mutex.RLock()
+			size := table.Len()
+			table.mutex.RUnlock()
+
+			offset := toInt64(v[1])
+			next := offset + toInt64(v[2])
+			if next < 0 || next > int64(size) {
+				return []any{offset, binaryOffsetError(next, size)}
+			}
+
+			return []any{next, nil}
+		},
+
+		"binary_size": func(v ...any) []any {
+			argsCheck(v, 1, 1, "string")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			_, fields := inter.parseBinaryFormat(v[BUILTIN_SPECIALS].(string), x, y)
+
+			var size int64
+			for _, field := range fields {
+				switch field.Code {
+				case 's':
+					size += int64(field.Count)
+				case 'p', 'z':
+					inter.throw("Format has fields of variable size.", x, y)
+				default:
+					size += int64(binaryFieldSizes[field.Code] * field.Count)
+				}
+			}
+
+			return []any{size}
+		},
+	}
+)
+
+func init() {
+	maps.Copy(builtinFuncs, binaryFuncs)
+}
+
+// Parses formats like "<I2h10sz", the byte order prefix is one of < (default), >, ! and =, spaces are ignored
+func (inter *Interpreter) parseBinaryFormat(format string, x, y int) (byteOrder, []BinaryField) {
+	var order byteOrder = binary.LittleEndian
+	if len(format) > 0 {
+		if o, ok := binaryByteOrders[format[0]]; ok {
+			order = o
+			format = format[1:]
+		}
+	}
+
+	fields := []BinaryField{}
+	for i := 0; i < len(format); i++ {
+		if format[i] == ' ' {
+			continue
+		}
+
+		count := -1
+		for ; i < len(format) && format[i] >= '0' && format[i] <= '9'; i++ {
+			count = max(count, 0)*10 + int(format[i]-'0')
+		}
+		if i == len(format) {
+			inter.throw("Binary format ends with a count.", x, y)
+		}
+
+		code := format[i]
+		if _, ok := binaryFieldSizes[code]; !ok && code != 's' && code != 'p' && code != 'z' {
+			inter.throw("Unknown binary format code '%c'.", x, y, code)
+		}
+		if count == -1 {
+			count = 1
+		}
+		if code == 'p' && count != 1 && count != 2 && count != 4 && count != 8 {
+			inter.throw("Length prefix of 'p' must be 1, 2, 4 or 8 bytes wide.", x, y)
+		}
+
+		fields = append(fields, BinaryField{Code: code, Count: count})
+	}
+
+	return order, fields
+}
+
+// Appends the values packed by the format to buf, argument is the number of the first value for error messages,
+// values that don't match the format are an error like short data when unpacking
+func (inter *Interpreter) packBinary(buf []byte, format string, values []any, argument, x, y int) ([]byte, error) {
+	order, fields := inter.parseBinaryFormat(format, x, y)
+
+	//Returns the next value and its argument number
+	i := 0
+	next := func() (any, int, error) {
+		if i >= len(values) {
+			return nil, 0, errors.New("not enough values for the binary format")
+		}
+		i++
+
+		return values[i-1], argument + i - 1, nil
+	}
+
+	for _, field := range fields {
+		switch field.Code {
+		case 'x':
+			buf = append(buf, make([]byte, field.Count)...)
+		case 's':
+			//Fixed-size strings are truncated or padded with zeros
+			value, n, err := next()
+			if err != nil {
+				return nil, err
+			}
+			data := inter.byteData(value, n, x, y)
+			buf = append(buf, data[:min(len(data), field.Count)]...)
+			buf = append(buf, make([]byte, field.Count-min(len(data), field.Count))...)
+		case 'p':
+			value, n, err := next()
+			if err != nil {
+				return nil, err
+			}
+			data := inter.byteData(value, n, x, y)
+			width := field.Count * 8
+			if width < 64 && uint64(len(data)) >= 1<<width {
+				return nil, fmt.Errorf("value of argument #%d is too long for its %d byte length prefix", n, field.Count)
+			}
+			buf = appendBinaryUint(buf, order, uint64(len(data)), field.Count)
+			buf = append(buf, data...)
+		case 'z':
+			for range field.Count {
+				value, n, err := next()
+				if err != nil {
+					return nil, err
+				}
+				data := inter.byteData(value, n, x, y)
+				if bytes.IndexByte(data, 0) != -1 {
+					return nil, fmt.Errorf("value of argument #%d contains a zero byte", n)
+				}
+				buf = append(append(buf, data...), 0)
+			}
+		default:
+			for range field.Count {
+				value, n, err := next()
+				if err != nil {
+					return nil, err
+				}
+				if buf, err = inter.packBinaryValue(buf, order, field.Code, value, n, x, y); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if i < len(values) {
+		return nil, errors.New("too many values for the binary format")
+	}
+
+	return buf, nil
+}
+
+func (inter *Interpreter) packBinaryValue(buf []byte, order byteOrder, code byte, value any, argument, x, y int) ([]byte, error) {
+	size := binaryFieldSizes[code]
+
+	switch code {
+	case '?':
+		b, ok := value.(bool)
+		if !ok {
+			inter.throw("Invalid argument #%d. Expected %s.", x, y, argument, "bool")
+		}
+		if b {
+			return append(buf, 1), nil
+		}
+
+		return append(buf, 0), nil
+	case 'f', 'd':
+		if !checkDataType("number", value) {
+			inter.throw("Invalid argument #%d. Expected %s.", x, y, argument, "number")
+		}
+		if code == 'f' {
+			return order.AppendUint32(buf, math.Float32bits(float32(mustNTOF64(value)))), nil
+		}
+
+		return order.AppendUint64(buf, math.Float64bits(mustNTOF64(value))), nil
+	}
+
+	if !checkDataType("int", value) {
+		inter.throw("Invalid argument #%d. Expected %s.", x, y, argument, "int")
+	}
+
+	//Lowercase codes are signed, values must fit the width of the field
+	bits := size * 8
+	var fits bool
+	switch signed := code >= 'a'; {
+	case checkDataType("uint", value):
+		limit := uint64(math.MaxUint64)
+		if signed {
+			limit = 1<<(bits-1) - 1
+		} else if bits < 64 {
+			limit = 1<<bits - 1
+		}
+		fits = toUint64(value) <= limit
+	case signed:
+		n := toInt64(value)
+		fits = bits == 64 || (n >= -1<<(bits-1) && n < 1<<(bits-1))
+	default:
+		n := toInt64(value)
+		fits = n >= 0 && (bits == 64 || n < 1<<bits)
+	}
+	if !fits {
+		return nil, fmt.Errorf("value %v of argument #%d doesn't fit the '%c' field", value, argument, code)
+	}
+
+	return appendBinaryUint(buf, order, toUint64(value), size), nil
+}
+
+func appendBinaryUint(buf []byte, order byteOrder, n uint64, size int) []byte {
+	switch size {
+	case 1:
+		return append(buf, byte(n))
+	case 2:
+		return order.AppendUint16(buf, uint16(n))
+	case 4:
+		return order.AppendUint32(buf, uint32(n))
+	}
+
+	return order.AppendUint64(buf, n)
+}
+
+// Values read by the format from the offset and the offset after them, short data is an error rather than a failure
+func (inter *Interpreter) unpackBinary(format string, data []byte, offset int64, x, y int) ([]any, int64, error) {
+	order, fields := inter.parseBinaryFormat(format, x, y)
+
+	if offset < 0 || offset > int64(len(data)) {
+		return nil, offset, binaryOffsetError(offset, len(data))
+	}
+	data = data[offset:]
+	start := len(data)
+
+	take := func(n uint64) ([]byte, bool) {
+		if n > uint64(len(data)) {
+			return nil, false
+		}
+		taken := data[:n]
+		data = data[n:]
+
+		return taken, true
+	}
+
+	values := []any{}
+	for _, field := range fields {
+		switch field.Code {
+		case 'x':
+			if _, ok := take(uint64(field.Count)); !ok {
+				return nil, offset, errShortBinaryData
+			}
+		case 's':
+			b, ok := take(uint64(field.Count))
+			if !ok {
+				return nil, offset, errShortBinaryData
+			}
+			values = append(values, string(b))
+		case 'p':
+			prefix, ok := take(uint64(field.Count))
+			if !ok {
+				return nil, offset, errShortBinaryData
+			}
+			b, ok := take(readBinaryUint(prefix, order))
+			if !ok {
+				return nil, offset, errShortBinaryData
+			}
+			values = append(values, string(b))
+		case 'z':
+			for range field.Count {
+				end := bytes.IndexByte(data, 0)
+				if end == -1 {
+					return nil, offset, errors.New("binary data has no zero byte ending the string")
+				}
+				values = append(values, string(data[:end]))
+				data = data[end+1:]
+			}
+		default:
+			for range field.Count {
+				b, ok := take(uint64(binaryFieldSizes[field.Code]))
+				if !ok {
+					return nil, offset, errShortBinaryData
+				}
+				values = append(values, binaryValue(field.Code, readBinaryUint(b, order)))
+			}
+		}
+	}
+
+	return values, offset + int64(start-len(data)), nil
+}
+
+func binaryOffsetError(offset int64, size int) error {
+	return fmt.Errorf("offset %d is out of the binary data of %d bytes", offset, size)
+}
+
+func readBinaryUint(b []byte, order byteOrder) uint64 {
+	switch len(b) {
+	case 1:
+		return uint64(b[0])
+	case 2:
+		return uint64(order.Uint16(b))
+	case 4:
+		return uint64(order.Uint32(b))
+	}
+
+	return order.Uint64(b)
+}
+
+// Typed value of the field's bits
+func binaryValue(code byte, n uint64) any {
+	switch code {
+	case '?':
+		return n != 0
+	case 'b':
+		return int8(n)
+	case 'B':
+		return uint8(n)
+	case 'h':
+		return int16(n)
+	case 'H':
+		return uint16(n)
+	case 'i':
+		return int32(n)
+	case 'I':
+		return uint32(n)
+	case 'q':
+		return int64(n)
+	case 'Q':
+		return n
+	case 'f':
+		return math.Float32frombits(uint32(n))
+	}
+
+	return math.Float64frombits(n)
+}
@@ -1,21 +1,36 @@
 package main
 
+import (
+	"fmt"
+	"math"
+	"math/big"
+	mathbits "math/bits"
+)
+
 var (
 	binOperations = map[string]func(inter *Interpreter, a, b any, x, y int) any{
 		"add": func(inter *Interpreter, a, b any, x, y int) any {
 			aType, bType := getValueType(a), getValueType(b)
 			if aType != bType {
-				throw(inter.CurrentFileName, "Unable to perform operation add or concat on values with different data types: '%s' and '%s'.", x, y, aType, bType)
+				inter.throw("Unable to perform operation add or concat on values with different data types: '%s' and '%s'.", x, y, aType, bType)
 			}
 
-			if checkDataType("int", a) && checkDataType("int", b) {
+			if checkDataType("uint", a) && checkDataType("uint", b) {
 				bits := twoDigitStr(aType[1:])
 
-				return toInt(toInt64(a)+toInt64(b), -bits)
-			} else if checkDataType("uint", a) && checkDataType("uint", b) {
-				bits := twoDigitStr(aType[1:])
+				if inter.IsChecked() && uintOverflows("add", toUint64(a), toUint64(b), bits) {
+					inter.throw("Integer overflow in operation add on '%s' values.", x, y, aType)
+				}
 
 				return toUint(toUint64(a)+toUint64(b), bits)
+			} else if checkDataType("int", a) && checkDataType("int", b) {
+				bits := twoDigitStr(aType[1:])
+
+				if inter.IsChecked() && intOverflows("add", toInt64(a), toInt64(b), bits) {
+					inter.throw("Integer overflow in operation add on '%s' values.", x, y, aType)
+				}
+
+				return toInt(toInt64(a)+toInt64(b), -bits)
 			} else if checkDataType("float", a) && checkDataType("float", b) {
 				bits := twoDigitStr(aType[1:])
 
@@ -26,24 +41,34 @@ var (
 			} else if checkType[string](a) && checkType[string](b) {
 
 				return a.(string) + b.(string)
+			} else if isBigNumber(a) {
+				return inter.bigOperation("add", a, b, x, y)
 			}
-			throw(inter.CurrentFileName, "Unable to perform operation add or concat on non-number and non-string values: %s and %s.", x, y, getValueType(a), getValueType(b))
+			inter.throw("Unable to perform operation add or concat on non-number and non-string values: %s and %s.", x, y, getValueType(a), getValueType(b))
 			return nil
 		},
 		"sub": func(inter *Interpreter, a, b any, x, y int) any {
 			aType, bType := getValueType(a), getValueType(b)
 			if aType != bType {
-				throw(inter.CurrentFileName, "Unable to perform operation sub on values with different data types: '%s' and '%s'.", x, y, aType, bType)
+				inter.throw("Unable to perform operation sub on values with different data types: '%s' and '%s'.", x, y, aType, bType)
 			}
 
-			if checkDataType("int", a) && checkDataType("int", b) {
+			if checkDataType("uint", a) && checkDataType("uint", b) {
 				bits := twoDigitStr(aType[1:])
 
-				return toInt(toInt64(a)-toInt64(b), -bits)
-			} else if checkDataType("uint", a) && checkDataType("uint", b) {
-				bits := twoDigitStr(aType[1:])
+				if inter.IsChecked() && uintOverflows("sub", toUint64(a), toUint64(b), bits) {
+					inter.throw("Integer overflow in operation sub on '%s' values.", x, y, aType)
+				}
 
 				return toUint(toUint64(a)-toUint64(b), bits)
+			} else if checkDataType("int", a) && checkDataType("int", b) {
+				bits := twoDigitStr(aType[1:])
+
+				if inter.IsChecked() && intOverflows("sub", toInt64(a), toInt64(b), bits) {
+					inter.throw("Integer overflow in operation sub on '%s' values.", x, y, aType)
+				}
+
+				return toInt(toInt64(a)-toInt64(b), -bits)
 			} else if checkDataType("float", a) && checkDataType("float", b) {
 				bits := twoDigitStr(aType[1:])
 
@@ -51,24 +76,37 @@ var (
 					return float32(mustNTOF64(a) - mustNTOF64(b))
 				}
 				return a.(float64) - b.(float64)
+			} else if isBigNumber(a) {
+				return inter.bigOperation("sub", a, b, x, y)
 			}
-			throw(inter.CurrentFileName, "Unable to perform operation sub on non-number values: %s and %s.", x, y, getValueType(a), getValueType(b))
+			inter.throw("Unable to perform operation sub on non-number values: %s and %s.", x, y, getValueType(a), getValueType(b))
 			return nil
 		},
 		"div": func(inter *Interpreter, a, b any, x, y int) any {
 			aType, bType := getValueType(a), getValueType(b)
 			if aType != bType {
-				throw(inter.CurrentFileName, "Unable to perform operation div on values with different data types: '%s' and '%s'.", x, y, aType, bType)
+				inter.throw("Unable to perform operation div on values with different data types: '%s' and '%s'.", x, y, aType, bType)
 			}
 
-			if checkDataType("int", a) && checkDataType("int", b) {
+			if checkDataType("uint", a) && checkDataType("uint", b) {
 				bits := twoDigitStr(aType[1:])
 
-				return toInt(toInt64(a)/toInt64(b), -bits)
-			} else if checkDataType("uint", a) && checkDataType("uint", b) {
-				bits := twoDigitStr(aType[1:])
+				if toUint64(b) == 0 {
+					inter.throw("Integer division by zero.", x, y)
+				}
 
 				return toUint(toUint64(a)/toUint64(b), bits)
+			} else if checkDataType("int", a) && checkDataType("int", b) {
+				bits := twoDigitStr(aType[1:])
+
+				if toInt64(b) == 0 {
+					inter.throw("Integer division by zero.", x, y)
+				}
+				if inter.IsChecked() && intOverflows("div", toInt64(a), toInt64(b), bits) {
+					inter.throw("Integer overflow in operation div on '%s' values.", x, y, aType)
+				}
+
+				return toInt(toInt64(a)/toInt64(b), -bits)
 			} else if checkDataType("float", a) && checkDataType("float", b) {
 				bits := twoDigitStr(aType[1:])
 
@@ -76,24 +114,69 @@ var (
 					return float32(mustNTOF64(a) / mustNTOF64(b))
 				}
 				return a.(float64) / b.(float64)
+			} else if isBigNumber(a) {
+				return inter.bigOperation("div", a, b, x, y)
 			}
-			throw(inter.CurrentFileName, "Unable to perform operation div on non-number values: %s and %s.", x, y, getValueType(a), getValueType(b))
+			inter.throw("Unable to perform operation div on non-number values: %s and %s.", x, y, getValueType(a), getValueType(b))
 			return nil
 		},
-		"mul": func(inter *Interpreter, a, b any, x, y int) any {
+		"mod": func(inter *Interpreter, a, b any, x, y int) any {
 			aType, bType := getValueType(a), getValueType(b)
 			if aType != bType {
-				throw(inter.CurrentFileName, "Unable to perform operation sub on values with different data types: '%s' and '%s'.", x, y, aType, bType)
+				inter.throw("Unable to perform operation mod on values with different data types: '%s' and '%s'.", x, y, aType, bType)
 			}
 
-			if checkDataType("int", a) && checkDataType("int", b) {
+			if checkDataType("uint", a) && checkDataType("uint", b) {
 				bits := twoDigitStr(aType[1:])
 
-				return toInt(toInt64(a)*toInt64(b), -bits)
-			} else if checkDataType("uint", a) && checkDataType("uint", b) {
+				if toUint64(b) == 0 {
+					inter.throw("Integer modulo by zero.", x, y)
+				}
+
+				return toUint(toUint64(a)%toUint64(b), bits)
+			} else if checkDataType("int", a) && checkDataType("int", b) {
+				bits := twoDigitStr(aType[1:])
+
+				if toInt64(b) == 0 {
+					inter.throw("Integer modulo by zero.", x, y)
+				}
+
+				return toInt(toInt64(a)%toInt64(b), -bits)
+			} else if checkDataType("float", a) && checkDataType("float", b) {
+				bits := twoDigitStr(aType[1:])
+
+				if bits == 32 {
+					return float32(math.Mod(mustNTOF64(a), mustNTOF64(b)))
+				}
+				return math.Mod(a.(float64), b.(float64))
+			} else if isBigNumber(a) {
+				return inter.bigOperation("mod", a, b, x, y)
+			}
+			inter.throw("Unable to perform operation mod on non-number values: %s and %s.", x, y, getValueType(a), getValueType(b))
+			return nil
+		},
+		"mul": func(inter *Interpreter, a, b any, x, y int) any {
+			aType, bType := getValueType(a), getValueType(b)
+			if aType != bType {
+				inter.throw("Unable to perform operation sub on values with different data types: '%s' and '%s'.", x, y, aType, bType)
+			}
+
+			if checkDataType("uint", a) && checkDataType("uint", b) {
 				bits := twoDigitStr(aType[1:])
 
+				if inter.IsChecked() && uintOverflows("mul", toUint64(a), toUint64(b), bits) {
+					inter.throw("Integer overflow in operation mul on '%s' values.", x, y, aType)
+				}
+
 				return toUint(toUint64(a)*toUint64(b), bits)
+			} else if checkDataType("int", a) && checkDataType("int", b) {
+				bits := twoDigitStr(aType[1:])
+
+				if inter.IsChecked() && intOverflows("mul", toInt64(a), toInt64(b), bits) {
+					inter.throw("Integer overflow in operation mul on '%s' values.", x, y, aType)
+				}
+
+				return toInt(toInt64(a)*toInt64(b), -bits)
 			} else if checkDataType("float", a) && checkDataType("float", b) {
 				bits := twoDigitStr(aType[1:])
 
@@ -101,100 +184,314 @@ var (
 					return float32(mustNTOF64(a) * mustNTOF64(b))
 				}
 				return a.(float64) * b.(float64)
+			} else if isBigNumber(a) {
+				return inter.bigOperation("mul", a, b, x, y)
 			}
-			throw(inter.CurrentFileName, "Unable to perform operation sub on non-number values: %s and %s.", x, y, getValueType(a), getValueType(b))
+			inter.throw("Unable to perform operation sub on non-number values: %s and %s.", x, y, getValueType(a), getValueType(b))
 			return nil
 		},
 
 		"bitor": func(inter *Interpreter, a, b any, x, y int) any {
 			aType, bType := getValueType(a), getValueType(b)
 			if aType != bType {
-				throw(inter.CurrentFileName, "Unable to perform operation bitor on values with different data types: '%s' and '%s'.", x, y, aType, bType)
+				inter.throw("Unable to perform operation bitor on values with different data types: '%s' and '%s'.", x, y, aType, bType)
 			}
 
-			if checkDataType("int", a) && checkDataType("int", b) {
+			if checkDataType("uint", a) && checkDataType("uint", b) {
 				bits := twoDigitStr(aType[1:])
 
-				return toInt(toInt64(a)|toInt64(b), -bits)
-			} else if checkDataType("uint", a) && checkDataType("uint", b) {
+				return toUint(toUint64(a)|toUint64(b), bits)
+			} else if checkDataType("int", a) && checkDataType("int", b) {
 				bits := twoDigitStr(aType[1:])
 
-				return toUint(toUint64(a)|toUint64(b), bits)
+				return toInt(toInt64(a)|toInt64(b), -bits)
 			}
-			throw(inter.CurrentFileName, "Unable to perform operation bitor on non-integer values: %s and %s.", x, y, getValueType(a), getValueType(b))
+			inter.throw("Unable to perform operation bitor on non-integer values: %s and %s.", x, y, getValueType(a), getValueType(b))
 			return nil
 		},
 
 		"greater": func(inter *Interpreter, a, b any, x, y int) any {
 			aType, bType := getValueType(a), getValueType(b)
 			if aType != bType {
-				throw(inter.CurrentFileName, "Unable to perform operation greater on values with different data types: '%s' and '%s'.", x, y, aType, bType)
+				inter.throw("Unable to perform operation greater on values with different data types: '%s' and '%s'.", x, y, aType, bType)
 			}
 
-			if checkDataType("int", a) && checkDataType("int", b) {
-				return toInt64(a) > toInt64(b)
-			} else if checkDataType("uint", a) && checkDataType("uint", b) {
+			if checkDataType("uint", a) && checkDataType("uint", b) {
 				return toUint64(a) > toUint64(b)
+			} else if checkDataType("int", a) && checkDataType("int", b) {
+				return toInt64(a) > toInt64(b)
 			} else if checkDataType("float", a) && checkDataType("float", b) {
 				return mustNTOF64(a) > mustNTOF64(b)
+			} else if checkType[string](a) && checkType[string](b) {
+				return a.(string) > b.(string)
+			} else if isBigNumber(a) {
+				return bigCompare(a, b) > 0
 			}
-			throw(inter.CurrentFileName, "Unable to perform operation greater on non-number values: %s and %s.", x, y, getValueType(a), getValueType(b))
+			inter.throw("Unable to perform operation greater on non-number and non-string values: %s and %s.", x, y, getValueType(a), getValueType(b))
 			return nil
 		},
 		"less": func(inter *Interpreter, a, b any, x, y int) any {
 			aType, bType := getValueType(a), getValueType(b)
 			if aType != bType {
-				throw(inter.CurrentFileName, "Unable to perform operation less on values with different data types: '%s' and '%s'.", x, y, aType, bType)
+				inter.throw("Unable to perform operation less on values with different data types: '%s' and '%s'.", x, y, aType, bType)
 			}
 
-			if checkDataType("int", a) && checkDataType("int", b) {
-				return toInt64(a) < toInt64(b)
-			} else if checkDataType("uint", a) && checkDataType("uint", b) {
+			if checkDataType("uint", a) && checkDataType("uint", b) {
 				return toUint64(a) < toUint64(b)
+			} else if checkDataType("int", a) && checkDataType("int", b) {
+				return toInt64(a) < toInt64(b)
 			} else if checkDataType("float", a) && checkDataType("float", b) {
 				return mustNTOF64(a) < mustNTOF64(b)
+			} else if checkType[string](a) && checkType[string](b) {
+				return a.(string) < b.(string)
+			} else if isBigNumber(a) {
+				return bigCompare(a, b) < 0
 			}
-			throw(inter.CurrentFileName, "Unable to perform operation less on non-number values: %s and %s.", x, y, getValueType(a), getValueType(b))
+			inter.throw("Unable to perform operation less on non-number and non-string values: %s and %s.", x, y, getValueType(a), getValueType(b))
 			return nil
 		},
 		"greatereq": func(inter *Interpreter, a, b any, x, y int) any {
 			aType, bType := getValueType(a), getValueType(b)
 			if aType != bType {
-				throw(inter.CurrentFileName, "Unable to perform operation greater/equals on values with different data types: '%s' and '%s'.", x, y, aType, bType)
+				inter.throw("Unable to perform operation greater/equals on values with different data types: '%s' and '%s'.", x, y, aType, bType)
 			}
 
-			if checkDataType("int", a) && checkDataType("int", b) {
-				return toInt64(a) >= toInt64(b)
-			} else if checkDataType("uint", a) && checkDataType("uint", b) {
+			if checkDataType("uint", a) && checkDataType("uint", b) {
 				return toUint64(a) >= toUint64(b)
+			} else if checkDataType("int", a) && checkDataType("int", b) {
+				return toInt64(a) >= toInt64(b)
 			} else if checkDataType("float", a) && checkDataType("float", b) {
 				return mustNTOF64(a) >= mustNTOF64(b)
+			} else if checkType[string](a) && checkType[string](b) {
+				return a.(string) >= b.(string)
+			} else if isBigNumber(a) {
+				return bigCompare(a, b) >= 0
 			}
-			throw(inter.CurrentFileName, "Unable to perform operation greater/equals on non-number values: %s and %s.", x, y, getValueType(a), getValueType(b))
+			inter.throw("Unable to perform operation greater/equals on non-number and non-string values: %s and %s.", x, y, getValueType(a), getValueType(b))
 			return nil
 		},
 		"lesseq": func(inter *Interpreter, a, b any, x, y int) any {
 			aType, bType := getValueType(a), getValueType(b)
 			if aType != bType {
-				throw(inter.CurrentFileName, "Unable to perform operation less/equals on values with different data types: '%s' and '%s'.", x, y, aType, bType)
+				inter.throw("Unable to perform operation less/equals on values with different data types: '%s' and '%s'.", x, y, aType, bType)
 			}
 
-			if checkDataType("int", a) && checkDataType("int", b) {
-				return toInt64(a) <= toInt64(b)
-			} else if checkDataType("uint", a) && checkDataType("uint", b) {
+			if checkDataType("uint", a) && checkDataType("uint", b) {
 				return toUint64(a) <= toUint64(b)
+			} else if checkDataType("int", a) && checkDataType("int", b) {
+				return toInt64(a) <= toInt64(b)
 			} else if checkDataType("float", a) && checkDataType("float", b) {
 				return mustNTOF64(a) <= mustNTOF64(b)
+			} else if checkType[string](a) && checkType[string](b) {
+				return a.(string) <= b.(string)
+			} else if isBigNumber(a) {
+				return bigCompare(a, b) <= 0
 			}
-			throw(inter.CurrentFileName, "Unable to perform operation less/equals on non-number values: %s and %s.", x, y, getValueType(a), getValueType(b))
+			inter.throw("Unable to perform operation less/equals on non-number and non-string values: %s and %s.", x, y, getValueType(a), getValueType(b))
 			return nil
 		},
 
 		"equals": func(inter *Interpreter, a, b any, x, y int) any {
-			return a == b
+			return deepEqual(a, b, nil)
 		},
 		"notequals": func(inter *Interpreter, a, b any, x, y int) any {
-			return a != b
+			return !deepEqual(a, b, nil)
+		},
+		"is": func(inter *Interpreter, a, b any, x, y int) any {
+			return a == b
 		},
 	}
 )
+
+// Compares tables by their elements and instances by their fields, pairs that are already being compared are considered equal,
+// numbers are promoted to a common type like operands of ==
+func deepEqual(a, b any, visited map[[2]any]bool) bool {
+	if !strictMode {
+		a, b = promoteOperands(a, b)
+	}
+
+	if a == b {
+		return true
+	}
+
+	switch a := a.(type) {
+	case *Map:
+		b, ok := b.(*Map)
+		if !ok || a == nil || b == nil || a.Len() != b.Len() {
+			return false
+		}
+
+		if visited == nil {
+			visited = map[[2]any]bool{}
+		}
+		if visited[[2]any{a, b}] {
+			return true
+		}
+		visited[[2]any{a, b}] = true
+
+		for key, aCell := range a.AllFromFront() {
+			bCell, ok := b.Get(key)
+			if !ok || !deepEqual(aCell.Get(), bCell.Get(), visited) {
+				return false
+			}
+		}
+
+		return true
+	case *StructObject:
+		b, ok := b.(*StructObject)
+		if !ok || a == nil || b == nil || a.Identifier != b.Identifier || len(a.Fields) != len(b.Fields) {
+			return false
+		}
+
+		if visited == nil {
+			visited = map[[2]any]bool{}
+		}
+		if visited[[2]any{a, b}] {
+			return true
+		}
+		visited[[2]any{a, b}] = true
+
+		for name, aField := range a.Fields {
+			bField, ok := b.Fields[name]
+			if !ok || !deepEqual(aField.Value.Get(), bField.Value.Get(), visited) {
+				return false
+			}
+		}
+
+		return true
+	case *big.Int, *Decimal:
+		return getValueType(a) == getValueType(b) && bigCompare(a, b) == 0
+	}
+
+	return false
+}
+
+// Converts number operands to a common type: integer literals take the type of the other operand if they fit in it,
+// floats win over integers, wider types win over narrower ones, and mixing signed with unsigned integers gives a signed
+// integer twice as wide as the unsigned one, integers mixed with bigint or decimal become these types,
+// u64 has no common type with signed integers, so these operands are left as they are and operations reject them
+func promoteOperands(a, b any) (any, any) {
+	if isBigNumber(a) || isBigNumber(b) {
+		return promoteBigOperands(a, b)
+	}
+
+	a, b = adaptLiteral(a, b), adaptLiteral(b, a)
+
+	if !checkDataType("number", a) || !checkDataType("number", b) {
+		return a, b
+	}
+
+	aType, bType := getValueType(a), getValueType(b)
+	if aType == bType {
+		return a, b
+	}
+
+	dataType := promotedType(aType, bType)
+	if dataType == "" {
+		return a, b
+	}
+
+	a, _ = assertType(a, dataType)
+	b, _ = assertType(b, dataType)
+
+	return a, b
+}
+
+// Literals that don't fit in the type of the other operand become i64(or u64 if they are too big for it)
+func adaptLiteral(literal, other any) any {
+	if !checkDataType("number", other) {
+		return literal
+	}
+
+	var value int64
+	switch l := literal.(type) {
+	case rawint64:
+		value = int64(l)
+	case rawuint64:
+		if uint64(l) > math.MaxInt64 {
+			return uint64(l)
+		}
+		value = int64(l)
+	default:
+		return literal
+	}
+
+	dataType := getValueType(other)
+	bits := twoDigitStr(dataType[1:])
+
+	switch dataType[0] {
+	case 'i':
+		if bits < 64 && (value < -(1<<(bits-1)) || value >= 1<<(bits-1)) {
+			return value
+		}
+	case 'u':
+		if value < 0 || bits < 64 && value >= 1<<bits {
+			return value
+		}
+	}
+
+	adapted, _ := assertType(value, dataType)
+	return adapted
+}
+
+func promotedType(aType, bType string) string {
+	aBits, bBits := twoDigitStr(aType[1:]), twoDigitStr(bType[1:])
+
+	switch {
+	case aType[0] == 'f' || bType[0] == 'f':
+		if aType == "f64" || bType == "f64" {
+			return "f64"
+		}
+		return "f32"
+	case aType[0] == bType[0]:
+		return fmt.Sprintf("%c%d", aType[0], max(aBits, bBits))
+	case aType == "u64" || bType == "u64":
+		//Neither i64 nor u64 holds every value of the other
+		return ""
+	case aType[0] == 'u':
+		aBits = min(aBits*2, 64)
+	default:
+		bBits = min(bBits*2, 64)
+	}
+
+	return fmt.Sprintf("i%d", max(aBits, bBits))
+}
+
+// Checks if the result of the signed integer operation doesn't fit in the given amount of bits
+func intOverflows(operation string, a, b int64, bits int) bool {
+	var result int64
+	overflow := false
+
+	switch operation {
+	case "add":
+		result = a + b
+		overflow = a > 0 && b > 0 && result < 0 || a < 0 && b < 0 && result >= 0
+	case "sub":
+		result = a - b
+		overflow = b < 0 && result < a || b > 0 && result > a
+	case "mul":
+		result = a * b
+		overflow = a != 0 && (result/a != b || a == -1 && b == math.MinInt64)
+	case "div":
+		result = a / b
+		overflow = a == math.MinInt64 && b == -1
+	}
+
+	return overflow || !fitsType(result, fmt.Sprintf("i%d", bits))
+}
+
+// Checks if the result of the unsigned integer operation doesn't fit in the given amount of bits
+func uintOverflows(operation string, a, b uint64, bits int) bool {
+	var result, carry uint64
+
+	switch operation {
+	case "add":
+		result, carry = mathbits.Add64(a, b, 0)
+	case "sub":
+		result, carry = mathbits.Sub64(a, b, 0)
+	case "mul":
+		carry, result = mathbits.Mul64(a, b)
+	}
+
+	return carry != 0 || !fitsType(result, fmt.Sprintf("u%d", bits))
+}
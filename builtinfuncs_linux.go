@@ -5,6 +5,7 @@ import (
 	"math"
 	"runtime"
 	"strconv"
+	"sync"
 	"syscall"
 	"time"
 	"unsafe"
@@ -23,7 +24,7 @@ var (
 		},
 
 		"print": func(v ...any) []any {
-			fmt.Println(format(v[BUILTIN_SPECIALS:]...))
+			writeStdout(format(v[BUILTIN_SPECIALS:]...) + "\n")
 			return nil
 		},
 
@@ -32,10 +33,14 @@ var (
 
 			v = v[BUILTIN_SPECIALS:]
 
-			table := v[0].(*orderedmap.OrderedMap[any, any])
+			table := v[0].(*Map)
 			key := v[1]
 
+			table.mutex.Lock()
+			defer table.mutex.Unlock()
+
 			table.Delete(key)
+			table.toMemory()
 			return nil
 		},
 
@@ -44,19 +49,13 @@ var (
 			inter := v[2].(*Interpreter)
 
 			if len(v) == 0 {
-				throw(inter.CurrentFileName, "Function must have one argument.", x, y)
+				inter.throw("Function must have one argument.", x, y)
 			}
 
 			v = v[BUILTIN_SPECIALS:]
 
-			switch t := v[0].(type) {
-			case float64:
-				time.Sleep(time.Duration(t * float64(time.Second)))
-			case int64:
-				time.Sleep(time.Duration(t * int64(time.Millisecond)))
-			default:
-				throw(inter.CurrentFileName, "Time value must be a number.", x, y)
-			}
+			//Numbers are milliseconds like timeouts of select, time.Duration values are exact
+			time.Sleep(inter.toDuration(v[0], x, y))
 			return nil
 		},
 
@@ -66,10 +65,10 @@ var (
 
 			v = v[BUILTIN_SPECIALS:]
 			if len(v) <= 0 {
-				throw(inter.CurrentFileName, "Function requires one or more arguments.", x, y)
+				inter.throw("Function requires one or more arguments.", x, y)
 			}
 
-			throw(inter.CurrentFileName, format(v...), x, y)
+			inter.throw(format(v...), x, y)
 			return nil
 		},
 
@@ -83,6 +82,9 @@ var (
 			a := v[0]
 			switch a := a.(type) {
 			case *Map:
+				a.mutex.RLock()
+				defer a.mutex.RUnlock()
+
 				return []any{int64(a.Len())}
 			case string:
 				return []any{int64(len(a))}
@@ -96,7 +98,7 @@ var (
 
 				return []any{int64(lastFieldLayout.Offset + lastFieldLayout.Size)}
 			default:
-				throw(inter.CurrentFileName, "Cannot get lenght of non-string, non-table or non-instance value.", x, y)
+				inter.throw("Cannot get lenght of non-string, non-table or non-instance value.", x, y)
 			}
 			return nil
 		},
@@ -124,18 +126,18 @@ var (
 				n, err := strconv.ParseFloat(str, 64)
 				switch err {
 				case strconv.ErrSyntax:
-					throw(inter.CurrentFileName, "Syntax error while trying to parse number value.", x, y)
+					inter.throw("Syntax error while trying to parse number value.", x, y)
 				case strconv.ErrRange:
-					throw(inter.CurrentFileName, "Number value is out of range.", x, y)
+					inter.throw("Number value is out of range.", x, y)
 				}
 				return []any{n}
 			} else {
 				n, err := strconv.ParseInt(str, 0, 64)
 				switch err {
 				case strconv.ErrSyntax:
-					throw(inter.CurrentFileName, "Syntax error while trying to parse number value.", x, y)
+					inter.throw("Syntax error while trying to parse number value.", x, y)
 				case strconv.ErrRange:
-					throw(inter.CurrentFileName, "Number value is out of range.", x, y)
+					inter.throw("Number value is out of range.", x, y)
 				}
 
 				return []any{n}
@@ -283,9 +285,11 @@ var (
 
 			m := &Map{
 				OrderedMap: orderedmap.NewOrderedMap[any, *Cell](),
+				DataType:   dataType,
 				Pointers:   []any{},
 				Layout:     []string{},
 				Mem:        []byte{},
+				mutex:      &sync.RWMutex{},
 			}
 
 			for i := 0; i < length; i++ {
@@ -303,7 +307,7 @@ func loadLibraryIntoScope(interpreter_filename, importPath string, node *Externa
 }
 
 func syscallAddress(inter *Interpreter, node Node, argsLen uint, argsValues [][]Node, addr uintptr) (uintptr, uintptr, error) {
-	throw(inter.CurrentFileName, "Cannot perform function pointer call on Unix based OS.", node.Position(), node.Line())
+	inter.throw("Cannot perform function pointer call on Unix based OS.", node.Position(), node.Line())
 
 	return 0, 0, nil
 }
@@ -332,7 +336,7 @@ func valueToPtr(inter *Interpreter, v any, x, y int) (uintptr, any) {
 		return 0, nil
 	default:
 		fmt.Printf("%T\n", val)
-		throw(inter.CurrentFileName, "Unsupported type.", x, y)
+		inter.throw("Unsupported type.", x, y)
 	}
 	return 0, nil
 }
@@ -2,11 +2,11 @@ package main
 
 import (
 	"errors"
-	"fmt"
 	"log"
 	"math"
 	"runtime"
 	"strconv"
+	"sync"
 	"syscall"
 	"time"
 	"unsafe"
@@ -25,7 +25,7 @@ var (
 		},
 
 		"print": func(v ...any) []any {
-			fmt.Println(format(v[BUILTIN_SPECIALS:]...))
+			writeStdout(format(v[BUILTIN_SPECIALS:]...) + "\n")
 			return nil
 		},
 
@@ -34,10 +34,14 @@ var (
 
 			v = v[BUILTIN_SPECIALS:]
 
-			table := v[0].(*orderedmap.OrderedMap[any, any])
+			table := v[0].(*Map)
 			key := v[1]
 
+			table.mutex.Lock()
+			defer table.mutex.Unlock()
+
 			table.Delete(key)
+			table.toMemory()
 			return nil
 		},
 
@@ -46,21 +50,13 @@ var (
 			inter := v[2].(*Interpreter)
 
 			if len(v) == 0 {
-				throw(inter.CurrentFileName, "Function must have one argument.", x, y)
+				inter.throw("Function must have one argument.", x, y)
 			}
 
 			v = v[BUILTIN_SPECIALS:]
 
-			switch t := v[0].(type) {
-			case float64, float32:
-				time.Sleep(time.Duration(mustNTOF64(t) * float64(time.Second)))
-			case int64, int32, int16, int8:
-				time.Sleep(time.Duration(toInt64(t) * int64(time.Millisecond)))
-			case uint64, uint32, uint16, uint8:
-				time.Sleep(time.Duration(toUint64(t) * uint64(time.Millisecond)))
-			default:
-				throw(inter.CurrentFileName, "Time value must be a number.", x, y)
-			}
+			//Numbers are milliseconds like timeouts of select, time.Duration values are exact
+			time.Sleep(inter.toDuration(v[0], x, y))
 			return nil
 		},
 
@@ -70,10 +66,10 @@ var (
 
 			v = v[BUILTIN_SPECIALS:]
 			if len(v) <= 0 {
-				throw(inter.CurrentFileName, "Function requires one or more arguments.", x, y)
+				inter.throw("Function requires one or more arguments.", x, y)
 			}
 
-			throw(inter.CurrentFileName, format(v...), x, y)
+			inter.throw(format(v...), x, y)
 			return nil
 		},
 
@@ -87,6 +83,9 @@ var (
 			a := v[0]
 			switch a := a.(type) {
 			case *Map:
+				a.mutex.RLock()
+				defer a.mutex.RUnlock()
+
 				return []any{int64(a.Len())}
 			case string:
 				return []any{int64(len(a))}
@@ -100,7 +99,7 @@ var (
 
 				return []any{int64(lastFieldLayout.Offset + lastFieldLayout.Size)}
 			default:
-				throw(inter.CurrentFileName, "Cannot get lenght of non-string, non-table or non-instance value.", x, y)
+				inter.throw("Cannot get lenght of non-string, non-table or non-instance value.", x, y)
 			}
 			return nil
 		},
@@ -146,18 +145,18 @@ var (
 				n, err := strconv.ParseFloat(str, 64)
 				switch err {
 				case strconv.ErrSyntax:
-					throw(inter.CurrentFileName, "Syntax error while trying to parse number value.", x, y)
+					inter.throw("Syntax error while trying to parse number value.", x, y)
 				case strconv.ErrRange:
-					throw(inter.CurrentFileName, "Number value is out of range.", x, y)
+					inter.throw("Number value is out of range.", x, y)
 				}
 				return []any{n}
 			} else {
 				n, err := strconv.ParseInt(str, 0, 64)
 				switch err {
 				case strconv.ErrSyntax:
-					throw(inter.CurrentFileName, "Syntax error while trying to parse number value.", x, y)
+					inter.throw("Syntax error while trying to parse number value.", x, y)
 				case strconv.ErrRange:
-					throw(inter.CurrentFileName, "Number value is out of range.", x, y)
+					inter.throw("Number value is out of range.", x, y)
 				}
 
 				return []any{n}
@@ -222,6 +221,7 @@ var (
 				Pointers: []any{},
 				Layout:   []string{},
 				Mem:      []byte{},
+				mutex:    &sync.RWMutex{},
 			}
 
 			for i := 0; i < length; i++ {
@@ -268,6 +268,7 @@ var (
 				Pointers:   []any{},
 				Layout:     []string{},
 				Mem:        []byte{},
+				mutex:      &sync.RWMutex{},
 			}
 
 			for i, v := range slice {
@@ -323,14 +324,14 @@ func valueToPtr(inter *Interpreter, v any, x, y int) (uintptr, any) {
 	case string:
 		utf16p, err := syscall.UTF16FromString(val)
 		if err != nil {
-			throw(inter.CurrentFileName, err.Error(), x, y)
+			inter.throw(err.Error(), x, y)
 		}
 
 		return uintptr(unsafe.Pointer(&utf16p[0])), utf16p
 	case nil:
 		return 0, nil
 	default:
-		throw(inter.CurrentFileName, "Unsupported type: '%s', unable to get pointer address.", x, y, getValueType(val))
+		inter.throw("Unsupported type: '%s', unable to get pointer address.", x, y, getValueType(val))
 	}
 	return 0, nil
 }
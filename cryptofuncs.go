@@ -0,0 +1,127 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"hash"
+	"hash/crc32"
+	"hash/fnv"
+	"maps"
+)
+
+var (
+	//Sums are big-endian, so crc32 and fnv digests read the same as their numbers in hex
+	hashAlgorithms = map[string]func() hash.Hash{
+		"md5":    md5.New,
+		"sha1":   sha1.New,
+		"sha256": sha256.New,
+		"sha512": sha512.New,
+		"crc32":  func() hash.Hash { return crc32.NewIEEE() },
+		"fnv32":  func() hash.Hash { return fnv.New32() },
+		"fnv32a": func() hash.Hash { return fnv.New32a() },
+		"fnv64":  func() hash.Hash { return fnv.New64() },
+		"fnv64a": func() hash.Hash { return fnv.New64a() },
+	}
+)
+
+var (
+	cryptoFuncs = map[string]func(v ...any) []any{
+		"crypto_hash": func(v ...any) []any {
+			argsCheck(v, 2, 2, "string", "any")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			h := inter.hashAlgorithm(v[0], x, y)()
+			h.Write(inter.byteData(v[1], 2, x, y))
+
+			return inter.digest(h.Sum(nil), x, y)
+		},
+
+		"crypto_hmac": func(v ...any) []any {
+			argsCheck(v, 3, 3, "string", "any", "any")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			mac := hmac.New(inter.hashAlgorithm(v[0], x, y), inter.byteData(v[1], 2, x, y))
+			mac.Write(inter.byteData(v[2], 3, x, y))
+
+			return inter.digest(mac.Sum(nil), x, y)
+		},
+
+		"crypto_equal": func(v ...any) []any {
+			argsCheck(v, 2, 2, "any", "any")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			//Takes the same time for any contents of equal length, so secrets like MACs can't be guessed byte by byte
+			a, b := inter.byteData(v[0], 1, x, y), inter.byteData(v[1], 2, x, y)
+
+			return []any{subtle.ConstantTimeCompare(a, b) == 1}
+		},
+	}
+)
+
+func init() {
+	maps.Copy(builtinFuncs, cryptoFuncs)
+}
+
+func (inter *Interpreter) hashAlgorithm(name any, x, y int) func() hash.Hash {
+	algorithm, ok := hashAlgorithms[name.(string)]
+	if !ok {
+		inter.throw("Unknown hash algorithm '%s', expected one of md5, sha1, sha256, sha512, crc32, fnv32, fnv32a, fnv64, fnv64a.", x, y, name)
+	}
+
+	return algorithm
+}
+
+// Digest as its hex string and u8 table
+func (inter *Interpreter) digest(sum []byte, x, y int) []any {
+	return []any{hex.EncodeToString(sum), inter.bytesTable(sum, x, y)}
+}
+
+// Bytes of a string or of a table of integers from 0 to 255, like the u8 tables of bytes() and make()
+func (inter *Interpreter) byteData(value any, argument, x, y int) []byte {
+	switch value := value.(type) {
+	case string:
+		return []byte(value)
+	case *Map:
+		cells := tableCells(value)
+
+		data := make([]byte, 0, len(cells))
+		for _, cell := range cells {
+			b := cell.Get()
+			if !checkDataType("int", b) || toInt64(b) < 0 || toInt64(b) > 255 {
+				inter.throw("Table of argument #%d must contain bytes only.", x, y, argument)
+			}
+
+			data = append(data, byte(toInt64(b)))
+		}
+
+		return data
+	}
+
+	inter.throw("Invalid argument #%d. Expected %s.", x, y, argument, "string or table")
+
+	return nil
+}
+
+func (inter *Interpreter) bytesTable(data []byte, x, y int) *Map {
+	table := newMap("u8", len(data))
+	for i, b := range data {
+		table.Set(int64(i), CLPTR(inter.CurrentScope, table.DataType, b, x, y))
+	}
+	table.ToMemory()
+
+	return table
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"maps"
+)
+
+var (
+	encodingFuncs = map[string]func(v ...any) []any{
+		"encoding_base64": func(v ...any) []any {
+			argsCheck(v, 2, 2, "any", "bool")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			return []any{base64Encoding(v[1].(bool)).EncodeToString(inter.byteData(v[0], 1, x, y))}
+		},
+
+		"encoding_base64decode": func(v ...any) []any {
+			argsCheck(v, 2, 2, "string", "bool")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			data, err := base64Encoding(v[1].(bool)).DecodeString(v[0].(string))
+			if err != nil {
+				return []any{newMap("u8", 0), err}
+			}
+
+			return []any{inter.bytesTable(data, x, y), nil}
+		},
+
+		"encoding_hex": func(v ...any) []any {
+			argsCheck(v, 1, 1, "any")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			return []any{hex.EncodeToString(inter.byteData(v[BUILTIN_SPECIALS], 1, x, y))}
+		},
+
+		"encoding_hexdecode": func(v ...any) []any {
+			argsCheck(v, 1, 1, "string")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			data, err := hex.DecodeString(v[BUILTIN_SPECIALS].(string))
+			if err != nil {
+				return []any{newMap("u8", 0), err}
+			}
+
+			return []any{inter.bytesTable(data, x, y), nil}
+		},
+
+		//Unlike bytes(), there is no terminating zero, so the table holds exactly the bytes of the string
+		"encoding_tobytes": func(v ...any) []any {
+			argsCheck(v, 1, 1, "string")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			return []any{inter.bytesTable([]byte(v[BUILTIN_SPECIALS].(string)), x, y)}
+		},
+
+		"encoding_tostring": func(v ...any) []any {
+			argsCheck(v, 1, 1, "table")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			return []any{string(inter.byteData(v[BUILTIN_SPECIALS], 1, x, y))}
+		},
+	}
+)
+
+func init() {
+	maps.Copy(builtinFuncs, encodingFuncs)
+}
+
+// Padded standard alphabet, or the URL and file name safe one
+func base64Encoding(url bool) *base64.Encoding {
+	if url {
+		return base64.URLEncoding
+	}
+
+	return base64.StdEncoding
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+var (
+	//Files opened by the fs module, their descriptors are shared with the streams
+	openFiles      = make(map[int64]*os.File)
+	openFilesMutex sync.Mutex
+)
+
+var (
+	fsFuncs = map[string]func(v ...any) []any{
+		"file_open": func(v ...any) []any {
+			argsCheck(v, 2, 2, "string", "bool")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			var file *os.File
+			var err error
+			if v[1].(bool) {
+				file, err = os.Create(v[0].(string))
+			} else {
+				file, err = os.Open(v[0].(string))
+			}
+			if err != nil {
+				return []any{int64(-1), err}
+			}
+
+			fd := newFd()
+
+			openFilesMutex.Lock()
+			defer openFilesMutex.Unlock()
+
+			openFiles[fd] = file
+
+			return []any{fd, nil}
+		},
+
+		"file_read": func(v ...any) []any {
+			argsCheck(v, 2, 2, "int", "int")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			file := inter.openFile(v[0], x, y)
+			if toInt64(v[1]) < 0 {
+				inter.throw("Attempt to read a negative amount of bytes.", x, y)
+			}
+
+			buf := make([]byte, toInt64(v[1]))
+			n, err := file.Read(buf)
+			if err == io.EOF {
+				err = nil
+			}
+
+			return []any{string(buf[:n]), err}
+		},
+
+		"file_write": func(v ...any) []any {
+			argsCheck(v, 2, 2, "int", "string")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			n, err := inter.openFile(v[0], x, y).WriteString(v[1].(string))
+
+			return []any{int64(n), err}
+		},
+
+		"file_seek": func(v ...any) []any {
+			argsCheck(v, 3, 3, "int", "int", "int")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			offset, err := inter.openFile(v[0], x, y).Seek(toInt64(v[1]), int(toInt64(v[2])))
+
+			return []any{offset, err}
+		},
+
+		"file_readall": func(v ...any) []any {
+			argsCheck(v, 1, 1, "int")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			content, err := io.ReadAll(inter.openFile(v[0], x, y))
+
+			return []any{string(content), err}
+		},
+
+		"file_close": func(v ...any) []any {
+			argsCheck(v, 1, 1, "int")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			file := inter.openFile(v[0], x, y)
+
+			openFilesMutex.Lock()
+			delete(openFiles, toInt64(v[0]))
+			openFilesMutex.Unlock()
+
+			return []any{file.Close()}
+		},
+
+		"fs_readfile": func(v ...any) []any {
+			argsCheck(v, 1, 1, "string")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			content, err := os.ReadFile(v[0].(string))
+
+			return []any{string(content), err}
+		},
+
+		"fs_writefile": func(v ...any) []any {
+			argsCheck(v, 2, 2, "string", "string")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			return []any{os.WriteFile(v[0].(string), []byte(v[1].(string)), 0644)}
+		},
+
+		"fs_stat": func(v ...any) []any {
+			argsCheck(v, 1, 1, "string")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			info, err := os.Stat(v[0].(string))
+			if err != nil {
+				return []any{"", int64(0), int64(0), float64(0), false, err}
+			}
+
+			mtime := float64(info.ModTime().UnixNano()) / 1e9
+
+			return []any{info.Name(), info.Size(), int64(info.Mode().Perm()), mtime, info.IsDir(), nil}
+		},
+
+		"fs_mkdir": func(v ...any) []any {
+			argsCheck(v, 1, 1, "string")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			return []any{os.MkdirAll(v[0].(string), 0755)}
+		},
+
+		"fs_remove": func(v ...any) []any {
+			argsCheck(v, 1, 1, "string")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			return []any{os.Remove(v[0].(string))}
+		},
+
+		"fs_removeall": func(v ...any) []any {
+			argsCheck(v, 1, 1, "string")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			return []any{os.RemoveAll(v[0].(string))}
+		},
+
+		"fs_rename": func(v ...any) []any {
+			argsCheck(v, 2, 2, "string", "string")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			return []any{os.Rename(v[0].(string), v[1].(string))}
+		},
+
+		"fs_readdir": func(v ...any) []any {
+			argsCheck(v, 1, 1, "string")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			entries, err := os.ReadDir(v[0].(string))
+
+			table := newMap("string", len(entries))
+			for i, entry := range entries {
+				table.Set(int64(i), CLPTR(inter.CurrentScope, table.DataType, entry.Name(), x, y))
+			}
+			table.ToMemory()
+
+			return []any{table, err}
+		},
+
+		"fs_walk": func(v ...any) []any {
+			argsCheck(v, 2, 2, "string", "func")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			function := v[1].(*FuncDec)
+
+			err := filepath.WalkDir(v[0].(string), func(path string, entry fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+
+				//Callback stops the walk by returning false
+				if proceed, ok := firstValue(inter.callback(function, x, y, path, entry.IsDir())).(bool); ok && !proceed {
+					return filepath.SkipAll
+				}
+
+				return nil
+			})
+
+			return []any{err}
+		},
+
+		"fs_glob": func(v ...any) []any {
+			argsCheck(v, 1, 1, "string")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			matches, err := filepath.Glob(v[0].(string))
+
+			table := newMap("string", len(matches))
+			for i, match := range matches {
+				table.Set(int64(i), CLPTR(inter.CurrentScope, table.DataType, match, x, y))
+			}
+			table.ToMemory()
+
+			return []any{table, err}
+		},
+	}
+)
+
+func init() {
+	maps.Copy(builtinFuncs, fsFuncs)
+}
+
+func (inter *Interpreter) openFile(fd any, x, y int) *os.File {
+	openFilesMutex.Lock()
+	defer openFilesMutex.Unlock()
+
+	file, ok := openFiles[toInt64(fd)]
+	if !ok {
+		inter.throw("Attempt to use a closed or non-existing file.", x, y)
+	}
+
+	return file
+}
@@ -6,14 +6,17 @@ import (
 	"errors"
 	"fmt"
 	"math"
-	"os"
+	"math/big"
 	"path/filepath"
 	"reflect"
 	"regexp"
 	"runtime"
 	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"unsafe"
+	"weak"
 
 	"github.com/elliotchance/orderedmap/v3"
 )
@@ -49,28 +52,39 @@ type Scope struct {
 	IsFunc, IsLoop bool
 	ImportedLibs   []string
 	MainScope      bool
+	Deferred       []*DeferredCall //Calls completed when the function or the program ends
+
+	mutex sync.RWMutex //Guards Data and Pointers, scopes are shared between tasks
 }
 
 type Cell struct {
-	Int64         int64
-	Int32         int32
-	Int16         int16
-	Int8          int8
-	Uint8         uint8
-	Uint16        uint16
-	Uint32        uint32
-	Uint64        uint64
-	Float64       float64
-	Float32       float32
-	BoolValue     bool
-	StringValue   string
-	StructValue   *Structure
-	InstanceValue *StructObject
-	TableValue    *Map
-	FuncValue     *FuncDec
-	PtrValue      uintptr
-	ErrorValue    error
-	AnyValue      any
+	Int64          int64
+	Int32          int32
+	Int16          int16
+	Int8           int8
+	Uint8          uint8
+	Uint16         uint16
+	Uint32         uint32
+	Uint64         uint64
+	Float64        float64
+	Float32        float32
+	BoolValue      bool
+	StringValue    string
+	StructValue    *Structure
+	InstanceValue  *StructObject
+	TableValue     *Map
+	FuncValue      *FuncDec
+	PtrValue       uintptr
+	ErrorValue     error
+	BigIntValue    *big.Int
+	DecimalValue   *Decimal
+	TaskValue      *Task
+	ChannelValue   *Channel
+	MutexValue     *sync.Mutex
+	ModuleValue    *Module
+	WaitGroupValue *sync.WaitGroup
+	RandomValue    *RandomGenerator
+	AnyValue       any
 
 	Bits uint8 //Shouldn't be used anywhere except *Cell structure methods
 
@@ -79,6 +93,24 @@ type Cell struct {
 	TempBuf  any
 
 	Scope *Scope
+
+	mutex sync.RWMutex
+}
+
+// Reads the value of a cell that can be shared between tasks
+func (cell *Cell) Load() any {
+	cell.mutex.RLock()
+	defer cell.mutex.RUnlock()
+
+	return cell.Get()
+}
+
+// Assigns the value to a cell that can be shared between tasks
+func (cell *Cell) Store(value any, x, y int) {
+	cell.mutex.Lock()
+	defer cell.mutex.Unlock()
+
+	cell.Set(value, false, x, y)
 }
 
 func (cell *Cell) InitFromRaw(value any, dataType string, nonptr bool, x, y int) {
@@ -92,8 +124,9 @@ func (cell *Cell) InitFromRaw(value any, dataType string, nonptr bool, x, y int)
 		rawInt64 := checkType[rawint64](value)
 		rawUint64 := checkType[rawuint64](value)
 		if !rawInt64 && !rawUint64 && cell.DataType != getValueType(value) {
-			throw(cell.Scope.Interpreter.CurrentFileName, "Type mismatch: expected '%s' got '%s'", x, y, cell.DataType, getValueType(value))
+			cell.Scope.Interpreter.throw("Type mismatch: expected '%s' got '%s'", x, y, cell.DataType, getValueType(value))
 		}
+		cell.checkLiteral(value, x, y)
 		if !rawInt64 {
 			value = rawint64(toInt64(value))
 		}
@@ -114,8 +147,9 @@ func (cell *Cell) InitFromRaw(value any, dataType string, nonptr bool, x, y int)
 		rawInt64 := checkType[rawint64](value)
 		rawUint64 := checkType[rawuint64](value)
 		if !rawInt64 && !rawUint64 && cell.DataType != getValueType(value) {
-			throw(cell.Scope.Interpreter.CurrentFileName, "Type mismatch: expected '%s' got '%s'", x, y, cell.DataType, getValueType(value))
+			cell.Scope.Interpreter.throw("Type mismatch: expected '%s' got '%s'", x, y, cell.DataType, getValueType(value))
 		}
+		cell.checkLiteral(value, x, y)
 		if !rawUint64 {
 			value = rawuint64(toUint64(value))
 		}
@@ -134,7 +168,7 @@ func (cell *Cell) InitFromRaw(value any, dataType string, nonptr bool, x, y int)
 		bits := uint8(twoDigitStr(dataType[1:]))
 
 		if !checkType[float64](value) && !checkType[float32](value) && !checkType[rawint64](value) {
-			throw(cell.Scope.Interpreter.CurrentFileName, "Type mismatch: expected '%s' got '%s'", x, y, cell.DataType, getValueType(value))
+			cell.Scope.Interpreter.throw("Type mismatch: expected '%s' got '%s'", x, y, cell.DataType, getValueType(value))
 		}
 
 		if i, ok := value.(rawint64); ok {
@@ -152,49 +186,62 @@ func (cell *Cell) InitFromRaw(value any, dataType string, nonptr bool, x, y int)
 		}
 	case "bool":
 		if !checkType[bool](value) {
-			throw(cell.Scope.Interpreter.CurrentFileName, "Type mismatch: expected '%s' got '%s'", x, y, cell.DataType, getValueType(value))
+			cell.Scope.Interpreter.throw("Type mismatch: expected '%s' got '%s'", x, y, cell.DataType, getValueType(value))
 		}
 
 		cell.Set(value.(bool), false, x, y)
 	case "pointer":
 		if !checkType[uintptr](value) {
-			throw(cell.Scope.Interpreter.CurrentFileName, "Type mismatch: expected '%s' got '%s'", x, y, cell.DataType, getValueType(value))
+			cell.Scope.Interpreter.throw("Type mismatch: expected '%s' got '%s'", x, y, cell.DataType, getValueType(value))
 		}
 
 		cell.Set(value.(uintptr), false, x, y)
 	case "string":
 		if !checkType[string](value) {
-			throw(cell.Scope.Interpreter.CurrentFileName, "Type mismatch: expected'%s' got '%s'", x, y, cell.DataType, getValueType(value))
+			cell.Scope.Interpreter.throw("Type mismatch: expected'%s' got '%s'", x, y, cell.DataType, getValueType(value))
 		}
 
 		cell.Set(value.(string), false, x, y)
 	case "func":
 		if !checkType[*FuncDec](value) {
-			throw(cell.Scope.Interpreter.CurrentFileName, "Type mismatch: expected'%s' got '%s'", x, y, cell.DataType, getValueType(value))
+			cell.Scope.Interpreter.throw("Type mismatch: expected'%s' got '%s'", x, y, cell.DataType, getValueType(value))
 		}
 
 		cell.Set(value.(*FuncDec), false, x, y)
 	case "struct":
 		if !checkType[*Structure](value) {
-			throw(cell.Scope.Interpreter.CurrentFileName, "Type mismatch: expected  '%s' got '%s'", x, y, cell.DataType, getValueType(value))
+			cell.Scope.Interpreter.throw("Type mismatch: expected  '%s' got '%s'", x, y, cell.DataType, getValueType(value))
 		}
 
 		cell.Set(value.(*Structure), false, x, y)
 	case "table":
 		if !checkType[*Map](value) && value != nil {
-			throw(cell.Scope.Interpreter.CurrentFileName, "Type mismatch: expected '%s' got '%s'", x, y, cell.DataType, getValueType(value))
+			cell.Scope.Interpreter.throw("Type mismatch: expected '%s' got '%s'", x, y, cell.DataType, getValueType(value))
 		}
 
 		cell.Set(value.(*Map), false, x, y)
+	case "bigint", "decimal":
+		value = adaptBigLiteral(value, dataType)
+		if getValueType(value) != dataType {
+			cell.Scope.Interpreter.throw("Type mismatch: expected '%s' got '%s'", x, y, cell.DataType, getValueType(value))
+		}
+
+		cell.Set(value, false, x, y)
+	case "task", "chan", "mutex", "waitgroup", "random", "module":
+		if getValueType(value) != dataType && value != nil {
+			cell.Scope.Interpreter.throw("Type mismatch: expected '%s' got '%s'", x, y, cell.DataType, getValueType(value))
+		}
+
+		cell.Set(value, false, x, y)
 	case "error":
-		if !checkType[error](value) {
-			throw(cell.Scope.Interpreter.CurrentFileName, "Type mismatch: expected '%s' got '%s'", x, y, cell.DataType, getValueType(value))
+		if !checkType[error](value) && value != nil {
+			cell.Scope.Interpreter.throw("Type mismatch: expected '%s' got '%s'", x, y, cell.DataType, getValueType(value))
 		}
 
-		cell.Set(value.(error), false, x, y)
+		cell.Set(value, false, x, y)
 	case "void":
 		if value != nil {
-			throw(cell.Scope.Interpreter.CurrentFileName, "Type mismatch: expected '%s' got '%s'", x, y, cell.DataType, getValueType(value))
+			cell.Scope.Interpreter.throw("Type mismatch: expected '%s' got '%s'", x, y, cell.DataType, getValueType(value))
 		}
 
 		cell.Set(nil, false, x, y)
@@ -210,7 +257,7 @@ func (cell *Cell) InitFromRaw(value any, dataType string, nonptr bool, x, y int)
 	default:
 		structureCell := cell.Scope.GetCell(cell.DataType)
 		if structureCell == nil || structureCell.DataType != "struct" {
-			throw(cell.Scope.Interpreter.CurrentFileName, "Unexisting type: '%s'", x, y, cell.DataType)
+			cell.Scope.Interpreter.throw("Unexisting type: '%s'", x, y, cell.DataType)
 		}
 
 		structObject, ok := value.(*StructObject)
@@ -219,14 +266,30 @@ func (cell *Cell) InitFromRaw(value any, dataType string, nonptr bool, x, y int)
 				cell.Set(value, false, x, y)
 				return
 			}
-			throw(cell.Scope.Interpreter.CurrentFileName, "Type mismatch: expected '%s' got '%s'", x, y, cell.DataType, getValueType(value))
+			cell.Scope.Interpreter.throw("Type mismatch: expected '%s' got '%s'", x, y, cell.DataType, getValueType(value))
 		}
 
 		cell.Set(value, false, x, y)
 	}
 }
 
+// Integer literals that don't fit in the cell type are errors in checked mode
+func (cell *Cell) checkLiteral(value any, x, y int) {
+	switch value.(type) {
+	case rawint64, rawuint64:
+		if cell.Scope.Interpreter.IsChecked() && !fitsType(value, cell.DataType) {
+			cell.Scope.Interpreter.throw("Value %s doesn't fit in '%s'.", x, y, format(value), cell.DataType)
+		}
+	}
+}
+
 func (cell *Cell) Set(value any, nonptr bool, x, y int) {
+	cell.checkLiteral(value, x, y)
+
+	if cell.DataType == "bigint" || cell.DataType == "decimal" {
+		value = adaptBigLiteral(value, cell.DataType)
+	}
+
 	switch avalue := value.(type) {
 	case rawint64:
 		bits := twoDigitStr(cell.DataType[1:])
@@ -245,7 +308,7 @@ func (cell *Cell) Set(value any, nonptr bool, x, y int) {
 	}
 
 	if cell.DataType != "" && cell.DataType != "any" && getValueType(value) != cell.DataType && value != nil {
-		throw(cell.Scope.Interpreter.CurrentFileName, "Type mismatch: expected 1'%s' got '%s'", x, y, cell.DataType, getValueType(value))
+		cell.Scope.Interpreter.throw("Type mismatch: expected 1'%s' got '%s'", x, y, cell.DataType, getValueType(value))
 	}
 
 	if cell.DataType == "" {
@@ -364,11 +427,59 @@ func (cell *Cell) Set(value any, nonptr bool, x, y int) {
 			cell.Ptr = unsafe.Pointer(&cell.PtrValue)
 		}
 	case "error":
-		cell.ErrorValue = value.(error)
+		cell.ErrorValue, _ = value.(error)
 
 		if !nonptr {
 			cell.Ptr = unsafe.Pointer(&cell.ErrorValue)
 		}
+	case "bigint":
+		cell.BigIntValue = value.(*big.Int)
+
+		if !nonptr {
+			cell.Ptr = unsafe.Pointer(cell.BigIntValue)
+		}
+	case "decimal":
+		cell.DecimalValue = value.(*Decimal)
+
+		if !nonptr {
+			cell.Ptr = unsafe.Pointer(cell.DecimalValue)
+		}
+	case "task":
+		cell.TaskValue, _ = value.(*Task)
+
+		if !nonptr {
+			cell.Ptr = unsafe.Pointer(cell.TaskValue)
+		}
+	case "chan":
+		cell.ChannelValue, _ = value.(*Channel)
+
+		if !nonptr {
+			cell.Ptr = unsafe.Pointer(cell.ChannelValue)
+		}
+	case "mutex":
+		cell.MutexValue, _ = value.(*sync.Mutex)
+
+		if !nonptr {
+			cell.Ptr = unsafe.Pointer(cell.MutexValue)
+		}
+	case "waitgroup":
+		cell.WaitGroupValue, _ = value.(*sync.WaitGroup)
+
+		if !nonptr {
+			cell.Ptr = unsafe.Pointer(cell.WaitGroupValue)
+		}
+	case "random":
+		cell.RandomValue, _ = value.(*RandomGenerator)
+
+		if !nonptr {
+			cell.Ptr = unsafe.Pointer(cell.RandomValue)
+		}
+	case "module":
+		cell.ModuleValue, _ = value.(*Module)
+
+		if !nonptr {
+			cell.Ptr = unsafe.Pointer(cell.ModuleValue)
+		}
 	case "any":
 		cell.AnyValue = value
 
@@ -420,6 +531,14 @@ func (cell *Cell) Clear() {
 	cell.StructValue = nil
 	cell.TableValue = nil
 	cell.TempBuf = nil
+	cell.BigIntValue = nil
+	cell.DecimalValue = nil
+	cell.TaskValue = nil
+	cell.ChannelValue = nil
+	cell.MutexValue = nil
+	cell.WaitGroupValue = nil
+	cell.RandomValue = nil
+	cell.ModuleValue = nil
 	cell.AnyValue = nil
 
 	cell.Ptr = nil
@@ -474,6 +593,22 @@ func (cell *Cell) Get() any {
 		return cell.FuncValue
 	case "error":
 		return cell.ErrorValue
+	case "bigint":
+		return cell.BigIntValue
+	case "decimal":
+		return cell.DecimalValue
+	case "task":
+		return cell.TaskValue
+	case "chan":
+		return cell.ChannelValue
+	case "mutex":
+		return cell.MutexValue
+	case "waitgroup":
+		return cell.WaitGroupValue
+	case "random":
+		return cell.RandomValue
+	case "module":
+		return cell.ModuleValue
 	case "any":
 		return cell.AnyValue
 	case "nil":
@@ -484,7 +619,11 @@ func (cell *Cell) Get() any {
 	panic("Idk")
 }
 
+// Address of the value of a cell that can be shared between tasks, assignments move it
 func (cell *Cell) GetAddress() unsafe.Pointer {
+	cell.mutex.RLock()
+	defer cell.mutex.RUnlock()
+
 	return cell.Ptr
 }
 
@@ -511,6 +650,14 @@ type Map struct {
 	Pointers []any
 	Layout   []string
 	Mem      []byte
+
+	Base  *Map                //Table that shares its memory with this slice
+	views []weak.Pointer[Map] //Slices that share the memory of this table
+
+	mutex *sync.RWMutex //Guards elements accessed by the interpreter, tables are shared between tasks and slices share the lock of their base
+
+	next      int64 //Key after the highest integer key, where append puts elements
+	nextKnown bool  //Tables built without newMap and tables whose highest key was deleted find it on the next append
 }
 
 func anyToBytes(v []any, m *Map) []byte {
@@ -567,6 +714,9 @@ func anyToBytes(v []any, m *Map) []byte {
 			m.Pointers[i] = nil
 
 			binary.Write(buf, binary.LittleEndian, 0)
+		case *big.Int, *Decimal, *Task, *Channel, *sync.Mutex, *sync.WaitGroup, *RandomGenerator, *Module:
+			m.Layout[i] = getValueType(t)
+			m.Pointers[i] = t
 		case *StructObject:
 			m.Layout[i] = "instance"
 			m.Pointers[i] = t
@@ -587,6 +737,8 @@ func bytesToAny(mem []byte, layout []string, pointers []any) []any {
 
 	for i, t := range layout {
 		switch t {
+		case "bigint", "decimal", "task", "chan", "mutex", "waitgroup", "random", "module":
+			res[i] = pointers[i]
 		case "table":
 			var ln uint32
 			binary.Read(r, binary.LittleEndian, &ln)
@@ -693,17 +845,35 @@ func bytesToAny(mem []byte, layout []string, pointers []any) []any {
 }
 
 func (m *Map) ToMemory() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.toMemory()
+}
+
+// Same as ToMemory for callers that already hold the lock of the table
+func (m *Map) toMemory() {
 	arrayBytes := anyToBytes(mapToSliceAny(m), m)
 	if len(arrayBytes) > len(m.Mem) {
 		m.Mem = arrayBytes
+		m.detach()
 	} else {
 		copy(m.Mem, arrayBytes)
+		m.syncViews(m, -1, -1)
 	}
 }
 
 func (m *Map) FromMemory(x, y int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.fromMemory(x, y)
+	m.syncViews(m, x, y)
+}
+
+// Reads the elements back from the memory, the caller holds the lock of the table
+func (m *Map) fromMemory(x, y int) {
 	s := bytesToAny(m.Mem, m.Layout, m.Pointers)
-	//ASDDADS
 	i := 0
 	for _, value := range m.AllFromFront() {
 		value.Set(s[i], false, x, y)
@@ -711,7 +881,48 @@ func (m *Map) FromMemory(x, y int) {
 	}
 }
 
+// Reads the memory written through one view back into the base and every other slice of it,
+// they all share the lock held by the caller
+func (m *Map) syncViews(from *Map, x, y int) {
+	if m.Base != nil {
+		m.Base.syncViews(from, x, y)
+		return
+	}
+
+	if m != from {
+		m.fromMemory(x, y)
+	}
+	m.views = slices.DeleteFunc(m.views, func(p weak.Pointer[Map]) bool {
+		view := p.Value()
+		if view == nil || view.Base != m {
+			return true
+		}
+		if view != from {
+			view.fromMemory(x, y)
+		}
+		return false
+	})
+}
+
+// Memory that grew was reallocated and is not shared anymore
+func (m *Map) detach() {
+	if m.Base != nil {
+		m.Base = nil
+		return
+	}
+
+	for _, p := range m.views {
+		if view := p.Value(); view != nil && view.Base == m {
+			view.Base = nil
+		}
+	}
+	m.views = nil
+}
+
 func (m *Map) Address() uintptr {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
 	if len(m.Mem) == 0 {
 		return 0
 	}
@@ -720,7 +931,9 @@ func (m *Map) Address() uintptr {
 }
 
 var (
-	filesBeingUsed = [][2]string{}
+	filesBeingUsed = [][2]string{}            //Files that are being completed, the last one imports the next
+	loadedModules  = make(map[string]*Module) //Completed modules by their absolute paths
+	filesMutex     sync.Mutex
 
 	osTags = []string{
 		"_" + runtime.GOOS,
@@ -743,6 +956,21 @@ func ptrToAny(v any) unsafe.Pointer {
 	return *(*unsafe.Pointer)(unsafe.Pointer(&v))
 }
 
+type DeferredCall struct {
+	Func *FuncDec
+	Args []any
+	X, Y int
+}
+
+// Queues the call on the nearest function scope or on the main scope
+func (scope *Scope) Defer(call *DeferredCall) {
+	for scope.Parent != nil && !scope.IsFunc && !scope.MainScope {
+		scope = scope.Parent
+	}
+
+	scope.Deferred = append(scope.Deferred, call)
+}
+
 func (scope *Scope) Add(key, value any, dataType string, x, y int) (success bool) {
 	if key == "_" {
 		return true
@@ -752,6 +980,9 @@ func (scope *Scope) Add(key, value any, dataType string, x, y int) (success bool
 	}
 	cell.InitFromRaw(value, dataType, false, x, y)
 
+	scope.mutex.Lock()
+	defer scope.mutex.Unlock()
+
 	scope.Data[key] = cell
 	scope.Pointers[cell.Ptr] = cell
 	switch value := value.(type) {
@@ -759,16 +990,16 @@ func (scope *Scope) Add(key, value any, dataType string, x, y int) (success bool
 		for _, field := range value.Fields {
 			fcell := field.Value
 
-			scope.Pointers[fcell.Ptr] = fcell
+			scope.Pointers[fcell.GetAddress()] = fcell
 		}
 		for _, field := range value.Methods {
 			fcell := field.Func
 
-			scope.Pointers[fcell.Ptr] = fcell
+			scope.Pointers[fcell.GetAddress()] = fcell
 		}
 	case *Map:
-		for _, vcell := range value.AllFromFront() {
-			scope.Pointers[vcell.Ptr] = vcell
+		for _, vcell := range tableCells(value) {
+			scope.Pointers[vcell.GetAddress()] = vcell
 		}
 	}
 
@@ -779,18 +1010,33 @@ func (scope *Scope) Set(key, value any, x, y int) (success bool) {
 	if key == "_" {
 		return true
 	}
-	if oldvalue, ok := scope.Data[key]; ok {
-		switch oldvalue.Get().(type) {
+	scope.mutex.RLock()
+	cell, ok := scope.Data[key]
+	scope.mutex.RUnlock()
+
+	if ok {
+		cell.mutex.Lock()
+		defer cell.mutex.Unlock()
+
+		switch cell.Get().(type) {
 		case *Structure, *FuncDec:
-			throw(scope.Interpreter.CurrentFileName, "Assignment to non-variable value", x, y)
+			scope.Interpreter.throw("Assignment to non-variable value", x, y)
 		}
 
-		cell := scope.Data[key]
+		scope.mutex.Lock()
 		if cell.Ptr != nil {
 			delete(scope.Pointers, cell.Ptr)
 		}
+		scope.mutex.Unlock()
+
 		cell.Set(value, false, x, y)
 
+		scope.mutex.Lock()
+		if cell.Ptr != nil {
+			scope.Pointers[cell.Ptr] = cell
+		}
+		scope.mutex.Unlock()
+
 		return true
 	} else if scope.Parent != nil {
 		return scope.Parent.Set(key, value, x, y)
@@ -799,9 +1045,12 @@ func (scope *Scope) Set(key, value any, x, y int) (success bool) {
 }
 
 func (scope *Scope) Get(key any) (any, bool) {
+	scope.mutex.RLock()
 	v, ok := scope.Data[key]
+	scope.mutex.RUnlock()
+
 	if ok {
-		return v.Get(), true
+		return v.Load(), true
 	} else if scope.Parent != nil {
 		return scope.Parent.Get(key)
 	}
@@ -809,9 +1058,12 @@ func (scope *Scope) Get(key any) (any, bool) {
 }
 
 func (scope *Scope) GetWithAddress(ptr unsafe.Pointer) any {
+	scope.mutex.RLock()
 	v, ok := scope.Pointers[ptr]
+	scope.mutex.RUnlock()
+
 	if ok {
-		return v.Get()
+		return v.Load()
 	} else if scope.Parent != nil {
 		return scope.Parent.GetWithAddress(ptr)
 	}
@@ -819,7 +1071,10 @@ func (scope *Scope) GetWithAddress(ptr unsafe.Pointer) any {
 }
 
 func (scope *Scope) GetCellWithAddress(ptr unsafe.Pointer) *Cell {
+	scope.mutex.RLock()
 	v, ok := scope.Pointers[ptr]
+	scope.mutex.RUnlock()
+
 	if ok {
 
 		return v
@@ -830,7 +1085,10 @@ func (scope *Scope) GetCellWithAddress(ptr unsafe.Pointer) *Cell {
 }
 
 func (scope *Scope) GetCell(key any) *Cell {
+	scope.mutex.RLock()
 	v, ok := scope.Data[key]
+	scope.mutex.RUnlock()
+
 	if ok {
 		return v
 	} else if scope.Parent != nil {
@@ -990,7 +1248,7 @@ func (s *StructObject) ToMemoryLayout(layout []FieldLayout) []byte {
 			binary.LittleEndian.PutUint32(mem[offset:], math.Float32bits(val.Get().(float32)))
 		case "bool":
 			mem[offset] = byte(toUint64(val.Get()))
-		case "string", "table":
+		case "string", "table", "handle":
 			binary.LittleEndian.PutUint64(mem[offset:], uint64(uintptr(val.Ptr)))
 		case "instance":
 			//binary.LittleEndian.PutUint64(mem[offset:], uint64(uintptr(val.Ptr)))
@@ -1060,6 +1318,8 @@ func (s *StructObject) FromMemoryLayout(layout []FieldLayout, x, y int) {
 		case "bool":
 			v := mem[offset]
 			s.Set(lf.Name, v == 1, x, y)
+		case "handle":
+			//C code can't replace Go values
 		/*case "instance":
 		ptr := binary.LittleEndian.Uint64(mem[offset:])
 		if ptr == 0 {
@@ -1198,6 +1458,10 @@ func toInt64(v any) int64 {
 		return int64(val)
 	case uintptr:
 		return int64(val)
+	case *big.Int:
+		return val.Int64()
+	case *Decimal:
+		return val.Int().Int64()
 	default:
 		return 0
 	}
@@ -1236,6 +1500,10 @@ func toUint64(v any) uint64 {
 			return 1
 		}
 		return 0
+	case *big.Int:
+		return val.Uint64()
+	case *Decimal:
+		return val.Int().Uint64()
 	default:
 		return 0
 	}
@@ -1277,6 +1545,9 @@ func (s *StructObject) Layout() []FieldLayout {
 			size, align, typ = 8, 8, "ptr"
 		case "bool":
 			size, align, typ = 1, 1, "bool"
+		case "task", "chan", "mutex", "waitgroup", "random", "module":
+			//Go values stay in the cell, the memory only holds their address
+			size, align, typ = ptrSize, ptrSize, "handle"
 		default:
 			switch v := cell.Get().(type) {
 			case *StructObject:
@@ -1311,7 +1582,7 @@ func (s *StructObject) Layout() []FieldLayout {
 func (structObj *StructObject) Get(fieldName string) (any, bool) {
 	field, ok := structObj.Fields[fieldName]
 	if ok {
-		return field.Value.Get(), true
+		return field.Value.Load(), true
 	}
 
 	method, ok := structObj.Methods[fieldName]
@@ -1356,7 +1627,7 @@ func (structObj *StructObject) Set(fieldName string, value any, x, y int) bool {
 	for _, field := range structObj.Fields {
 		cell := field.Value
 		if field.Identifier == fieldName {
-			cell.Set(value, false, x, y)
+			cell.Store(value, x, y)
 
 			return true
 		}
@@ -1365,7 +1636,7 @@ func (structObj *StructObject) Set(fieldName string, value any, x, y int) bool {
 	for _, method := range structObj.Methods {
 		if method.Identifier == fieldName {
 			funcDecl := method.Func.Get().(*FuncDec)
-			throw(structObj.scope.Interpreter.CurrentFileName, "Cannot assign value to a instance's method.", funcDecl.X, funcDecl.Y)
+			structObj.scope.Interpreter.throw("Cannot assign value to a instance's method.", funcDecl.X, funcDecl.Y)
 		}
 	}
 	return false
@@ -1404,7 +1675,8 @@ func format(v ...any) string {
 			formated += fmt.Sprintf("%p", a) + suffix
 		case *StructObject:
 			if a == nil {
-				return formated + format(nil) + suffix
+				formated += format(nil) + suffix
+				continue
 			}
 
 			structFormat := "%s{%s}"
@@ -1442,52 +1714,58 @@ func format(v ...any) string {
 	return formated
 }
 
-func importModule(path string, mainScope *Scope, x, y int) {
+func importModule(node *Import, path string, mainScope *Scope) {
 	if !strings.HasSuffix(path, fileType) {
 		path += fileType
 	}
 	pathNS, _ := strings.CutSuffix(path, fileType)
-	absPath := getAbsPath(path)
 
-	for _, tag := range osTags {
-		absPathTag := absPath + tag
-		var finalPath string
+	filesMutex.Lock()
+	importerDir := filepath.Dir(filesBeingUsed[len(filesBeingUsed)-1][0])
+	filesMutex.Unlock()
 
-		for _, filePath := range filesBeingUsed {
-			if filePath[0] == absPathTag {
-				throwNoPos("Recursive or duplicate import of file '%s' detected.", filePath[1])
-			}
+	if absPath, ok := resolveModule(pathNS, importerDir); ok {
+		filesMutex.Lock()
+		module, loaded := loadedModules[absPath]
+		cycle := importCycle(absPath, path)
+		filesMutex.Unlock()
+
+		if len(cycle) > 0 {
+			mainScope.Interpreter.throw("Import cycle detected: %s.", node.X, node.Y, cycle)
 		}
 
-		finalPath = pathNS + tag + fileType
-		_, err := os.Stat(finalPath)
-		if err != nil {
-			if os.IsNotExist(err) {
-				finalPath = filepath.Join(libs, pathNS) + tag + fileType
+		if !loaded {
+			module = run(absPath, path, false)
+			module.Name = pathNS
 
-				_, errS := os.Stat(finalPath)
-				if errS != nil {
-					continue
-				}
-			} else {
-				continue
-			}
+			filesMutex.Lock()
+			loadedModules[absPath] = module
+			filesBeingUsed = filesBeingUsed[:len(filesBeingUsed)-1]
+			filesMutex.Unlock()
 		}
 
-		moduleData := run(finalPath, path, false)
+		mainScope.Interpreter.AddModule(node, module, mainScope)
+		return
+	}
+	mainScope.Interpreter.throw("Invalid file or library '%s'", node.X, node.Y, path)
+}
 
-		for k, v := range moduleData {
-			cell := &Cell{
-				Scope: mainScope,
-			}
-			cell.Set(v.Get(), false, x, y)
+// Returns the chain of files that leads back to the file being imported, files being completed form a stack
+func importCycle(absPath, relPath string) string {
+	for i, filePath := range filesBeingUsed {
+		if filePath[0] != absPath {
+			continue
+		}
 
-			mainScope.Data[k] = cell
-			mainScope.Pointers[cell.Ptr] = cell
+		chain := []string{}
+		for _, filePath := range filesBeingUsed[i:] {
+			chain = append(chain, filePath[1])
 		}
-		return
+
+		return strings.Join(append(chain, relPath), " -> ")
 	}
-	throwNoPos("Invalid file or library '%s'", path)
+
+	return ""
 }
 
 func mapToSliceAny(m *Map) []any {
@@ -1516,6 +1794,14 @@ type Interpreter struct {
 	AST             []Node
 	CurrentScope    *Scope
 	UnableToImport  bool
+	Checked         bool                     //Set while completing a function with @checked attribute
+	CallSite        atomic.Pointer[CallSite] //Set while code of an imported module runs, cells of the scope are reached from other tasks
+}
+
+// Position where the file of the interpreter called into an imported module and the file of the module code that runs
+type CallSite struct {
+	X, Y int
+	File string
 }
 
 func NewInterpreter(filename string, ast []Node) *Interpreter {
@@ -1525,6 +1811,21 @@ func NewInterpreter(filename string, ast []Node) *Interpreter {
 	}
 }
 
+// Integer overflows and narrowing conversions throw errors in checked mode
+func (inter *Interpreter) IsChecked() bool {
+	return checkedMode || inter.Checked
+}
+
+// Errors inside of imported modules are reported at the call site along with their position in the module
+func (inter *Interpreter) throw(errForm string, x, y int, v ...any) {
+	if site := inter.CallSite.Load(); site != nil {
+		message := strings.TrimSuffix(fmt.Sprintf(errForm, v...), ".")
+		throw(inter.CurrentFileName, "%s (in %s:%d:%d)", site.X, site.Y, message, site.File, y, x)
+	}
+
+	throw(inter.CurrentFileName, errForm, x, y, v...)
+}
+
 func (inter *Interpreter) GetBinOpValue(node *BinOpNode) any {
 	if node.operator == "sub" && node.L == nil && node.R != nil {
 		value := inter.GetNodeValue(node.R)
@@ -1561,8 +1862,14 @@ func (inter *Interpreter) GetBinOpValue(node *BinOpNode) any {
 				return -value
 			}
 		}
+		switch value := value.(type) {
+		case *big.Int:
+			return new(big.Int).Neg(value)
+		case *Decimal:
+			return &Decimal{new(big.Int).Neg(value.Unscaled), value.Scale}
+		}
 		fmt.Printf("%T\n", value)
-		throw(inter.CurrentFileName, "Unable to use unary operator '-' on non-number value.", node.X, node.Y)
+		inter.throw("Unable to use unary operator '-' on non-number value.", node.X, node.Y)
 	}
 
 	err := "Cannot perform binary operations on multiple values at the same time."
@@ -1572,7 +1879,7 @@ func (inter *Interpreter) GetBinOpValue(node *BinOpNode) any {
 	returnL, ok := l.([]any)
 	if ok {
 		if len(returnL) > 1 {
-			throw(inter.CurrentFileName, err, node.X, node.Y)
+			inter.throw(err, node.X, node.Y)
 		}
 		l = returnL[0]
 	}
@@ -1580,7 +1887,7 @@ func (inter *Interpreter) GetBinOpValue(node *BinOpNode) any {
 	returnR, ok := r.([]any)
 	if ok {
 		if len(returnR) > 1 {
-			throw(inter.CurrentFileName, err, node.X, node.Y)
+			inter.throw(err, node.X, node.Y)
 		}
 		r = returnR[0]
 	}
@@ -1595,6 +1902,10 @@ func (inter *Interpreter) GetBinOpValue(node *BinOpNode) any {
 
 	f := binOperations[node.operator]
 
+	if !strictMode {
+		l, r = promoteOperands(l, r)
+	}
+
 	if checkType[rawint64](l) {
 		l = int64(l.(rawint64))
 	}
@@ -1626,6 +1937,8 @@ func (inter *Interpreter) GetNodeValue(node Node) any {
 		}
 	case *FloatNode:
 		return node.Value
+	case *BigNumNode:
+		return node.Value
 	case *StrNode:
 		return node.Value
 	case *BoolNode:
@@ -1638,22 +1951,37 @@ func (inter *Interpreter) GetNodeValue(node Node) any {
 
 		assertValue, ok := assertType(target, typeName)
 		if !ok {
-			throw(inter.CurrentFileName, "Error occured while tried to assert value type of '%s' to '%s'", node.X, node.Y, getValueType(target), typeName)
+			inter.throw("Error occured while tried to assert value type of '%s' to '%s'", node.X, node.Y, getValueType(target), typeName)
+		}
+		if inter.IsChecked() && !fitsType(target, typeName) {
+			inter.throw("Value %s doesn't fit in '%s'.", node.X, node.Y, format(target), typeName)
 		}
 
 		return assertValue
 	case *Brackets:
 		return inter.GetNodeValueS(node.Value, node.X, node.Y)
+	case *SliceNode:
+		sliceRange := &SliceRange{}
+		if len(node.Start) > 0 {
+			sliceRange.Start = inter.GetNodeValueS(node.Start, node.X, node.Y)
+		}
+		if len(node.End) > 0 {
+			sliceRange.End = inter.GetNodeValueS(node.End, node.X, node.Y)
+		}
+
+		return sliceRange
 	case *MapNode:
 		return inter.GetMap(node)
 	case *FuncDec:
 		return node
 	case *FuncCall:
 		return inter.CallFunction(node)
+	case *SpawnNode:
+		return inter.Spawn(node)
 	case *IdentNode:
 		v, found := inter.CurrentScope.Get(node.Value)
 		if !found {
-			throw(inter.CurrentFileName, "Variable '%s' doesn't exist", node.X, node.Y, node.Value)
+			inter.throw("Variable '%s' doesn't exist", node.X, node.Y, node.Value)
 		}
 
 		return v
@@ -1661,7 +1989,7 @@ func (inter *Interpreter) GetNodeValue(node Node) any {
 		return inter.NewStructObject(node)
 	case *GetPtrNode:
 		if node.Src == nil {
-			throw(inter.CurrentFileName, "Attempt to get a pointer of nothing.", node.X, node.Y)
+			inter.throw("Attempt to get a pointer of nothing.", node.X, node.Y)
 		}
 		srcNode := node.Src
 
@@ -1673,22 +2001,22 @@ func (inter *Interpreter) GetNodeValue(node Node) any {
 
 			cell := scope.GetCell(identifier)
 			if cell == nil {
-				throw(inter.CurrentFileName, "Attempt to get a pointer of non-existing value.", node.X, node.Y)
+				inter.throw("Attempt to get a pointer of non-existing value.", node.X, node.Y)
 			}
 			if cell.Ptr == nil {
-				throw(inter.CurrentFileName, "Attempt to get pointer of nil value.", node.X, node.Y)
+				inter.throw("Attempt to get pointer of nil value.", node.X, node.Y)
 			}
 
 			switch v := cell.Get().(type) {
 			case *FuncDec, *Structure:
-				throw(inter.CurrentFileName, "Cannot get a pointer of '%s' value", node.X, node.Y, getValueType(v))
+				inter.throw("Cannot get a pointer of '%s' value", node.X, node.Y, getValueType(v))
 			}
 
 			return uintptr(cell.Ptr) //uintptr(unsafe.Pointer(&cell.Ptr))
 		case *GetElementNode:
 			tableNode, keyNodes := inter.GetTableAndKeys(srcNode, []Node{})
 			if tableNode == nil {
-				throw(inter.CurrentFileName, "Attempt to index nothing.", srcNode.X, srcNode.Y)
+				inter.throw("Attempt to index nothing.", srcNode.X, srcNode.Y)
 			}
 
 			table := inter.GetNodeValue(tableNode)
@@ -1702,23 +2030,23 @@ func (inter *Interpreter) GetNodeValue(node Node) any {
 			case *Map, string:
 				cell := inter.GetTableCellByKeys(table, keys, srcNode, 0)
 
-				return cell.Ptr
+				return cell.GetAddress()
 			default:
-				throw(inter.CurrentFileName, "Cannot index non-table value.", node.X, node.Y)
+				inter.throw("Cannot index non-table value.", node.X, node.Y)
 			}
 		case *GetFieldNode:
 			cell := inter.GetInstanceFieldCell(srcNode)
 
-			return cell.Ptr
+			return cell.GetAddress()
 		}
 	case *GetFieldNode:
 		cell := inter.GetInstanceFieldCell(node)
 
-		return cell.Get()
+		return cell.Load()
 	case *GetElementNode:
 		tableNode, keyNodes := inter.GetTableAndKeys(node, []Node{})
 		if tableNode == nil {
-			throw(inter.CurrentFileName, "Attempt to index nothing.", node.X, node.Y)
+			inter.throw("Attempt to index nothing.", node.X, node.Y)
 		}
 
 		table := inter.GetNodeValue(tableNode)
@@ -1732,26 +2060,26 @@ func (inter *Interpreter) GetNodeValue(node Node) any {
 		case *Map, string:
 			return inter.GetTableValueByKeys(table, keys, node, 0)
 		default:
-			throw(inter.CurrentFileName, "Cannot index non-table or non-string value.", node.X, node.Y)
+			inter.throw("Cannot index non-table or non-string value.", node.X, node.Y)
 		}
 	}
-	throw(inter.CurrentFileName, "Invalid node '%s'.", node.Position(), node.Line(), getInterfaceType(node))
+	inter.throw("Invalid node '%s'.", node.Position(), node.Line(), getInterfaceType(node))
 	return nil
 }
 
 func (inter *Interpreter) GetNodeValueS(nodes []Node, x, y int) any {
 	if len(nodes) > 1 || len(nodes) == 0 {
-		throw(inter.CurrentFileName, "Value has more than one value or is empty", x, y)
+		inter.throw("Value has more than one value or is empty", x, y)
 	}
 	return inter.GetNodeValue(nodes[0])
 }
 
 func (inter *Interpreter) GetTableAndKeys(node *GetElementNode, keys []Node) (Node, []Node) {
 	if len(node.Map) > 1 {
-		throw(inter.CurrentFileName, "Cannot index more than one value at the same time", node.X, node.Y)
+		inter.throw("Cannot index more than one value at the same time", node.X, node.Y)
 	}
 	if len(node.Key) > 1 {
-		throw(inter.CurrentFileName, "Key cannot have more than one value", node.X, node.Y)
+		inter.throw("Key cannot have more than one value", node.X, node.Y)
 	}
 	keys = append(keys, node.Key[0])
 	switch mapNode := node.Map[0].(type) {
@@ -1781,21 +2109,32 @@ func (inter *Interpreter) GetTableValueByKeys(table any, keys []any, getElemN *G
 		table = tableCell.Get()
 	}
 
+	if sliceRange, ok := key.(*SliceRange); ok {
+		sliced := inter.SliceValue(table, sliceRange, getElemN.X, getElemN.Y)
+
+		if index+1 < len(keys) {
+			return inter.GetTableValueByKeys(sliced, keys, getElemN, index+1)
+		}
+		return sliced
+	}
+
 	switch table := table.(type) {
 	case *Map:
-		if !table.Has(key) {
+		key = inter.TableKeyFromEnd(table, key, getElemN.X, getElemN.Y)
+
+		table.mutex.RLock()
+		elem := table.GetElement(key)
+		table.mutex.RUnlock()
+
+		if elem == nil {
 			if index+1 < len(keys) {
-				throw(inter.CurrentFileName, "Attempt to index non-table value.", getElemN.X, getElemN.Y)
+				inter.throw("Attempt to index non-table value.", getElemN.X, getElemN.Y)
 			} else {
 				return nil
 			}
 		}
 
-		elem := table.GetElement(key)
-		var val any
-		if elem != nil {
-			val = elem.Value.Get()
-		}
+		val := elem.Value.Load()
 
 		if index+1 < len(keys) {
 			return inter.GetTableValueByKeys(val, keys, getElemN, index+1)
@@ -1803,25 +2142,118 @@ func (inter *Interpreter) GetTableValueByKeys(table any, keys []any, getElemN *G
 		return val
 	case string:
 		if !checkDataType("int", key) {
-			throw(inter.CurrentFileName, "Attempt to index string with non-integer value; '%s'", getElemN.X, getElemN.Y, getValueType(key))
+			inter.throw("Attempt to index string with non-integer value; '%s'", getElemN.X, getElemN.Y, getValueType(key))
 		}
 
+		chars := []rune(table)
+
 		i := int(toInt64(key))
-		if i >= len(table) || i < 0 {
-			throw(inter.CurrentFileName, "Attempt to index a character beyond the string limit.", getElemN.X, getElemN.Y)
+		if i < 0 {
+			i += len(chars)
+		}
+		if i >= len(chars) || i < 0 {
+			inter.throw("Attempt to index a character beyond the string limit.", getElemN.X, getElemN.Y)
 		}
 		if index+1 != len(keys) {
-			throw(inter.CurrentFileName, "Repeated indexing of a character is not allowed.", getElemN.X, getElemN.Y)
+			inter.throw("Repeated indexing of a character is not allowed.", getElemN.X, getElemN.Y)
 		}
 
-		char := string([]rune(table)[i])
+		char := string(chars[i])
 
 		return char
 	}
-	throw(inter.CurrentFileName, "Attempt to index non-table value.", getElemN.X, getElemN.Y)
+	inter.throw("Attempt to index non-table value.", getElemN.X, getElemN.Y)
+	return nil
+}
+
+type SliceRange struct {
+	Start, End any
+}
+
+// Converts slice bounds to the absolute positions, negative bound counts from the end
+func (inter *Interpreter) SliceBounds(start, end any, length, x, y int) (int, int) {
+	i, j := 0, length
+
+	if start != nil {
+		if !checkDataType("int", start) && !checkType[rawuint64](start) {
+			inter.throw("Slice bound must be an integer value; '%s'", x, y, getValueType(start))
+		}
+		i = int(toInt64(start))
+		if i < 0 {
+			i += length
+		}
+	}
+	if end != nil {
+		if !checkDataType("int", end) && !checkType[rawuint64](end) {
+			inter.throw("Slice bound must be an integer value; '%s'", x, y, getValueType(end))
+		}
+		j = int(toInt64(end))
+		if j < 0 {
+			j += length
+		}
+	}
+
+	if i < 0 || j > length || i > j {
+		inter.throw("Slice bounds [%d:%d] are out of range for value with length %d.", x, y, i, j, length)
+	}
+
+	return i, j
+}
+
+func (inter *Interpreter) SliceValue(value any, sliceRange *SliceRange, x, y int) any {
+	switch value := value.(type) {
+	case *Map:
+		//Slicing registers the slice in its base
+		value.mutex.Lock()
+		defer value.mutex.Unlock()
+
+		i, j := inter.SliceBounds(sliceRange.Start, sliceRange.End, value.Len(), x, y)
+
+		return value.Slice(inter.CurrentScope, i, j, x, y)
+	case string:
+		chars := []rune(value)
+		i, j := inter.SliceBounds(sliceRange.Start, sliceRange.End, len(chars), x, y)
+
+		return string(chars[i:j])
+	}
+	inter.throw("Attempt to slice non-table or non-string value.", x, y)
 	return nil
 }
 
+// Returns the key of the element counted from the end of the table if the key is negative and doesn't exist
+func (inter *Interpreter) TableKeyFromEnd(table *Map, key any, x, y int) any {
+	switch k := key.(type) {
+	case rawint64:
+		key = int64(k)
+	case rawuint64:
+		key = uint64(k)
+	}
+
+	i, ok := key.(int64)
+	if !ok || i >= 0 {
+		return key
+	}
+
+	table.mutex.RLock()
+	defer table.mutex.RUnlock()
+
+	if table.Has(key) {
+		return key
+	}
+
+	length := int64(table.Len())
+	if length+i < 0 {
+		inter.throw("Index %d is out of range for table with length %d.", x, y, i, length)
+	}
+
+	//Counting from the end needs the last key to be len-1, otherwise a sparse table would give a void element
+	if last := table.Back(); last.Key != length-1 || !table.Has(length+i) {
+		inter.throw("Index %d needs a table with keys 0 to len-1.", x, y, i)
+	}
+
+	return length + i
+}
+
 func (inter *Interpreter) GetTableCellByKeys(table any, keys []any, getElemN *GetElementNode, index int) *Cell {
 	if index >= len(keys) {
 		return nil
@@ -1835,34 +2267,38 @@ func (inter *Interpreter) GetTableCellByKeys(table any, keys []any, getElemN *Ge
 		key = uint64(key.(rawuint64))
 	}
 
+	if checkType[*SliceRange](key) {
+		inter.throw("Slice expression cannot be used as an element reference.", getElemN.X, getElemN.Y)
+	}
+
 	switch table := table.(type) {
 	case *Map:
-		if !table.Has(key) {
+		key = inter.TableKeyFromEnd(table, key, getElemN.X, getElemN.Y)
+
+		table.mutex.RLock()
+		elem := table.GetElement(key)
+		table.mutex.RUnlock()
+
+		if elem == nil {
 			if index+1 < len(keys) {
-				throw(inter.CurrentFileName, "Attempt to index non-table value.", getElemN.X, getElemN.Y)
+				inter.throw("Attempt to index non-table value.", getElemN.X, getElemN.Y)
 			} else {
 				return CLPTR(inter.CurrentScope, "void", nil, getElemN.X, getElemN.Y)
 			}
 		}
 
-		elem := table.GetElement(key)
-		var val *Cell
-		if elem != nil {
-			val = elem.Value
-		}
-
 		if index+1 < len(keys) {
-			return inter.GetTableCellByKeys(val.Get(), keys, getElemN, index+1)
+			return inter.GetTableCellByKeys(elem.Value.Load(), keys, getElemN, index+1)
 		}
-		return val
+		return elem.Value
 	}
-	throw(inter.CurrentFileName, "Attempt to index non-table value.", getElemN.X, getElemN.Y)
+	inter.throw("Attempt to index non-table value.", getElemN.X, getElemN.Y)
 	return nil
 }
 
 func (inter *Interpreter) GetStructAndFieldNames(node *GetFieldNode, fields []Node) (Node, []Node) {
 	if len(node.Field) > 1 {
-		throw(inter.CurrentFileName, "Cannot get value of more than one field at the same time.", node.X, node.Y)
+		inter.throw("Cannot get value of more than one field at the same time.", node.X, node.Y)
 	}
 	fields = append(fields, node.Field[0])
 	switch structNode := node.Struct.(type) {
@@ -1883,13 +2319,13 @@ func (inter *Interpreter) GetFieldValueByNames(structObj *StructObject, fieldNam
 
 	val, ok := structObj.Get(fieldName)
 	if !ok {
-		throw(inter.CurrentFileName, "Attempt to get a value of nonexistent field '%s'", getFieldN.X, getFieldN.Y, fieldName)
+		inter.throw("Attempt to get a value of nonexistent field '%s'", getFieldN.X, getFieldN.Y, fieldName)
 	}
 
 	if index+1 < len(fieldNames) {
 		nextStructObj, ok := val.(*StructObject)
 		if !ok {
-			throw(inter.CurrentFileName, "Attempt to get field of a non-structure value", getFieldN.X, getFieldN.Y)
+			inter.throw("Attempt to get field of a non-structure value", getFieldN.X, getFieldN.Y)
 		}
 
 		return inter.GetFieldValueByNames(nextStructObj, fieldNames, getFieldN, index+1)
@@ -1906,13 +2342,13 @@ func (inter *Interpreter) GetFieldCellByNames(structObj *StructObject, fieldName
 
 	val, ok := structObj.GetCell(fieldName)
 	if !ok {
-		throw(inter.CurrentFileName, "Attempt to get a value of nonexistent field '%s'", getFieldN.X, getFieldN.Y, fieldName)
+		inter.throw("Attempt to get a value of nonexistent field '%s'", getFieldN.X, getFieldN.Y, fieldName)
 	}
 
 	if index+1 < len(fieldNames) {
 		nextStructObj, ok := val.Get().(*StructObject)
 		if !ok {
-			throw(inter.CurrentFileName, "Attempt to get field of a non-structure value", getFieldN.X, getFieldN.Y)
+			inter.throw("Attempt to get field of a non-structure value", getFieldN.X, getFieldN.Y)
 		}
 
 		return inter.GetFieldCellByNames(nextStructObj, fieldNames, getFieldN, index+1)
@@ -1923,12 +2359,7 @@ func (inter *Interpreter) GetFieldCellByNames(structObj *StructObject, fieldName
 func (inter *Interpreter) GetInstanceFieldCell(getFieldNode *GetFieldNode) *Cell {
 	structObjNode, fieldNodes := inter.GetStructAndFieldNames(getFieldNode, []Node{})
 	if structObjNode == nil {
-		throw(inter.CurrentFileName, "Attempt to get field of nothing.", getFieldNode.X, getFieldNode.Y)
-	}
-
-	structObj, ok := inter.GetNodeValue(structObjNode).(*StructObject)
-	if !ok {
-		throw(inter.CurrentFileName, "Attempt to get field of a non-structure value.", structObjNode.Position(), structObjNode.Line())
+		inter.throw("Attempt to get field of nothing.", getFieldNode.X, getFieldNode.Y)
 	}
 
 	fields := make([]string, len(fieldNodes))
@@ -1936,12 +2367,31 @@ func (inter *Interpreter) GetInstanceFieldCell(getFieldNode *GetFieldNode) *Cell
 
 		fieldIdentNode, ok := fieldNode.(*IdentNode)
 		if !ok {
-			throw(inter.CurrentFileName, "Field name must be an identifier", fieldNode.Position(), fieldNode.Line())
+			inter.throw("Field name must be an identifier", fieldNode.Position(), fieldNode.Line())
 		}
 
 		fields[i] = fieldIdentNode.Value
 	}
 
+	value := inter.GetNodeValue(structObjNode)
+	if module, ok := value.(*Module); ok {
+		cell, ok := module.Exports[fields[0]]
+		if !ok {
+			inter.throw("Module '%s' doesn't export '%s'.", getFieldNode.X, getFieldNode.Y, module.Name, fields[0])
+		}
+		if len(fields) == 1 {
+			return cell
+		}
+
+		value = cell.Load()
+		fields = fields[1:]
+	}
+
+	structObj, ok := value.(*StructObject)
+	if !ok {
+		inter.throw("Attempt to get field of a non-structure value.", structObjNode.Position(), structObjNode.Line())
+	}
+
 	return inter.GetFieldCellByNames(structObj, fields, getFieldNode, 0)
 }
 
@@ -1960,9 +2410,9 @@ func (inter *Interpreter) GetMap(node *MapNode) *Map {
 		values, ok := value.([]any)
 		if ok {
 			if len(values) > 1 {
-				throw(inter.CurrentFileName, "Field cannot have more than one value.", element.X, element.Y)
+				inter.throw("Field cannot have more than one value.", element.X, element.Y)
 			} else if len(values) == 0 {
-				throw(inter.CurrentFileName, "Cannot assign a field cannot be an empty value.", element.X, element.Y)
+				inter.throw("Cannot assign a field cannot be an empty value.", element.X, element.Y)
 			}
 
 			m.Set(key, CLPTR(inter.CurrentScope, elemDataType, values[0], element.X, element.Y))
@@ -1971,7 +2421,14 @@ func (inter *Interpreter) GetMap(node *MapNode) *Map {
 		}
 	}
 
-	fmap := &Map{m, elemDataType, []any{}, []string{}, []byte{}}
+	fmap := &Map{
+		OrderedMap: m,
+		DataType:   elemDataType,
+		Pointers:   []any{},
+		Layout:     []string{},
+		Mem:        []byte{},
+		mutex:      &sync.RWMutex{},
+	}
 	fmap.ToMemory()
 
 	return fmap
@@ -2040,10 +2497,19 @@ func (inter *Interpreter) CallFunction(node *FuncCall) []any {
 			return result
 		}
 
+		if funcDec.Module != nil {
+			argsValues := make([][]Node, len(node.Arguments))
+			for i, argNode := range node.Arguments {
+				argsValues[i] = []Node{argNode}
+			}
+
+			return inter.CallFunctionValue(funcDec, node.X, node.Y, inter.CookValues(uint(len(node.Arguments)), argsValues, node.X, node.Y)...)
+		}
+
 		body := funcDec.Body
 
 		if len(node.Arguments) > len(funcDec.Arguments) {
-			throw(inter.CurrentFileName, "Attempt to pass more arguments to a function call than function actually need.", node.X, node.Y)
+			inter.throw("Attempt to pass more arguments to a function call than function actually need.", node.X, node.Y)
 		}
 
 		argsIdentifiers := funcDec.Arguments //[]IdentNode{}
@@ -2073,15 +2539,93 @@ func (inter *Interpreter) CallFunction(node *FuncCall) []any {
 			}
 		}
 
+		checked := inter.Checked
+		inter.Checked = funcDec.Checked
+		defer func() { inter.Checked = checked }()
+
 		_, _, value := inter.CompleteBody(body, true, false, addToScope...)
 
 		return value
 	default:
-		throw(inter.CurrentFileName, "Attempt to call a non-function object.", node.X, node.Y)
+		inter.throw("Attempt to call a non-function object.", node.X, node.Y)
 		return nil
 	}
 }
 
+// Calls a function with already cooked values, used by builtins that accept callbacks
+func (inter *Interpreter) CallFunctionValue(funcDec *FuncDec, x, y int, args ...any) []any {
+	if funcDec.Template != nil {
+		return funcDec.Template(append([]any{x, y, inter}, args...)...)
+	}
+
+	if len(args) > len(funcDec.Arguments) {
+		inter.throw("Attempt to pass more arguments to a function call than function actually need.", x, y)
+	}
+
+	addToScope := make([][3]any, 0, len(funcDec.Arguments)+1)
+	for i, argIdent := range funcDec.Arguments {
+		var value any
+		if i < len(args) {
+			value = args[i]
+		}
+
+		addToScope = append(addToScope, [3]any{argIdent.Value, value, funcDec.ArgumentsDataTypes[i].Value})
+	}
+	if funcDec.Self != nil {
+		addToScope = append(addToScope, [3]any{selfKeyword, funcDec.Self, funcDec.Self.Identifier})
+	}
+
+	checked := inter.Checked
+	inter.Checked = funcDec.Checked
+	defer func() { inter.Checked = checked }()
+
+	if funcDec.Module != nil {
+		current := inter.CurrentScope
+		inter.CurrentScope = funcDec.Module
+		defer func() { inter.CurrentScope = current }()
+	}
+
+	//Arguments are checked at the call site, types of the arguments are resolved in the scope of the function
+	argsScope := inter.CurrentScope
+	if funcDec.Module != nil {
+		argsScope = NewScope(inter, funcDec.Module)
+	}
+	for _, arg := range addToScope[:len(funcDec.Arguments)] {
+		CLPTR(argsScope, arg[2].(string), arg[1], x, y)
+	}
+
+	//Outermost call into another file is kept, callbacks back into the file report their own positions
+	fileScope := funcDec.Module
+	if fileScope == nil {
+		fileScope = funcDec.Scope
+	}
+	if fileScope != nil {
+		callSite := inter.CallSite.Load()
+		switch file := fileScope.Interpreter.CurrentFileName; {
+		case file == inter.CurrentFileName:
+			inter.CallSite.Store(nil)
+		case callSite == nil:
+			inter.CallSite.Store(&CallSite{X: x, Y: y, File: file})
+		default:
+			inter.CallSite.Store(&CallSite{X: callSite.X, Y: callSite.Y, File: file})
+		}
+		defer inter.CallSite.Store(callSite)
+	}
+
+	_, _, value := inter.CompleteBody(funcDec.Body, true, false, addToScope...)
+
+	for i, v := range value {
+		switch v := v.(type) {
+		case rawint64:
+			value[i] = int64(v)
+		case rawuint64:
+			value[i] = uint64(v)
+		}
+	}
+
+	return value
+}
+
 func (inter *Interpreter) CompleteBody(body []Node, isFunc, isLoop bool, addToScope ...[3]any) (end, skip bool, value []any) {
 	scope := NewScope(inter, inter.CurrentScope)
 	scope.IsFunc = isFunc
@@ -2089,6 +2633,9 @@ func (inter *Interpreter) CompleteBody(body []Node, isFunc, isLoop bool, addToSc
 
 	inter.Current(scope)
 	defer inter.Current(scope.Parent)
+	if isFunc {
+		defer inter.CompleteDeferred(scope)
+	}
 
 	for _, addToScopeElem := range addToScope {
 		ident := addToScopeElem[0].(string)
@@ -2120,29 +2667,48 @@ func (inter *Interpreter) CompleteBody(body []Node, isFunc, isLoop bool, addToSc
 	return false, false, nil
 }
 
+// Completes queued calls of the scope in reverse order
+func (inter *Interpreter) CompleteDeferred(scope *Scope) {
+	for i := len(scope.Deferred) - 1; i >= 0; i-- {
+		call := scope.Deferred[i]
+
+		inter.CallFunctionValue(call.Func, call.X, call.Y, call.Args...)
+	}
+	scope.Deferred = nil
+}
+
 func (inter *Interpreter) SetTableElementValue(table *Map, keys []any, value any, index int, x, y int) {
 	if index >= len(keys) {
 		return
 	}
 
 	key := keys[index]
+	if checkType[*SliceRange](key) {
+		inter.throw("Cannot assign a value to a slice expression.", x, y)
+	}
+	key = inter.TableKeyFromEnd(table, key, x, y)
 
+	table.mutex.RLock()
 	elem := table.GetElement(key)
+	table.mutex.RUnlock()
+
 	if elem != nil {
-		switch elem := elem.Value.Get().(type) {
+		switch elem := elem.Value.Load().(type) {
 		case *Map:
-			if index+1 >= len(keys) {
-				table.Set(key, CLPTR(inter.CurrentScope, table.DataType, value, x, y))
-
-				elem.ToMemory()
-				break
+			if index+1 < len(keys) {
+				inter.SetTableElementValue(elem, keys, value, index+1, x, y)
+				return
 			}
-			inter.SetTableElementValue(elem, keys, value, index+1, x, y)
-			return
 		}
 	}
-	table.Set(key, CLPTR(inter.CurrentScope, table.DataType, value, x, y))
-	table.ToMemory()
+
+	cell := CLPTR(inter.CurrentScope, table.DataType, value, x, y)
+
+	table.mutex.Lock()
+	defer table.mutex.Unlock()
+
+	table.Set(key, cell)
+	table.toMemory()
 }
 
 func (inter *Interpreter) SetInstanceFieldValue(instance *StructObject, fields []string, value any, index int, x, y int) {
@@ -2187,7 +2753,7 @@ func (inter *Interpreter) ScopeIsLoop(scope *Scope) bool {
 func (inter *Interpreter) SetElementValue(node *SetElem) {
 	tableNode, keyNodes := inter.GetTableAndKeys(node.Elem, []Node{})
 	if tableNode == nil {
-		throw(inter.CurrentFileName, "Attempt to index nothing", node.X, node.Y)
+		inter.throw("Attempt to index nothing", node.X, node.Y)
 	}
 
 	table := inter.GetNodeValue(tableNode)
@@ -2196,9 +2762,9 @@ func (inter *Interpreter) SetElementValue(node *SetElem) {
 		key := inter.GetNodeValue(keyNode)
 		if cookedValues, ok := key.([]any); ok {
 			if len(cookedValues) > 1 {
-				throw(inter.CurrentFileName, "Element's key cannot have more than one value.", tableNode.Position(), tableNode.Line())
+				inter.throw("Element's key cannot have more than one value.", tableNode.Position(), tableNode.Line())
 			} else if len(cookedValues) == 0 {
-				throw(inter.CurrentFileName, "Cannot assign an element's key an empty value.", tableNode.Position(), tableNode.Line())
+				inter.throw("Cannot assign an element's key an empty value.", tableNode.Position(), tableNode.Line())
 			}
 
 			key = cookedValues[0]
@@ -2213,14 +2779,14 @@ func (inter *Interpreter) SetElementValue(node *SetElem) {
 	case *Map:
 		inter.SetTableElementValue(table, keys, value, 0, node.X, node.Y)
 	default:
-		throw(inter.CurrentFileName, "Cannot index non-table value", node.X, node.Y)
+		inter.throw("Cannot index non-table value", node.X, node.Y)
 	}
 }
 
 func (inter *Interpreter) SetFieldValue(node *SetFieldNode) {
 	instanceNode, fieldNodes := inter.GetStructAndFieldNames(node.Field, []Node{})
 	if instanceNode == nil {
-		throw(inter.CurrentFileName, "Attempt to index nothing", node.X, node.Y)
+		inter.throw("Attempt to index nothing", node.X, node.Y)
 	}
 
 	instance := inter.GetNodeValue(instanceNode)
@@ -2234,8 +2800,10 @@ func (inter *Interpreter) SetFieldValue(node *SetFieldNode) {
 	switch instance := instance.(type) {
 	case *StructObject:
 		inter.SetInstanceFieldValue(instance, fields, value, 0, node.X, node.Y)
+	case *Module:
+		inter.throw("Cannot assign a value to a member of the module '%s'.", node.X, node.Y, instance.Name)
 	default:
-		throw(inter.CurrentFileName, "Cannot assign field of non-instance value", node.X, node.Y)
+		inter.throw("Cannot assign field of non-instance value", node.X, node.Y)
 	}
 }
 
@@ -2256,7 +2824,7 @@ func (inter *Interpreter) DeclareStructure(structDecl *StructDeclNode) {
 		Identifier: identifier,
 		Fields:     fields,
 	}, "struct", structDecl.X, structDecl.Y) {
-		throw(inter.CurrentFileName, "Attempt to declare the structure with the same name as the variable '%s'.", structDecl.X, structDecl.Y, identifier)
+		inter.throw("Attempt to declare the structure with the same name as the variable '%s'.", structDecl.X, structDecl.Y, identifier)
 	}
 }
 
@@ -2265,12 +2833,12 @@ func (inter *Interpreter) NewStructObject(structObjNode *StructNode) *StructObje
 
 	originalStructureAny, found := inter.CurrentScope.Get(identifier)
 	if !found {
-		throw(inter.CurrentFileName, "Attempt to make an instance of structure '%s' that doesn't exist", structObjNode.X, structObjNode.Y, structObjNode.Identifier.Value)
+		inter.throw("Attempt to make an instance of structure '%s' that doesn't exist", structObjNode.X, structObjNode.Y, structObjNode.Identifier.Value)
 	}
 
 	originalStructure, ok := originalStructureAny.(*Structure)
 	if originalStructure == nil || !ok {
-		throw(inter.CurrentFileName, "Attempt to make an instance of a nonexistent structure '%s'.", structObjNode.X, structObjNode.Y, identifier)
+		inter.throw("Attempt to make an instance of a nonexistent structure '%s'.", structObjNode.X, structObjNode.Y, identifier)
 	}
 
 	structObject := &StructObject{
@@ -2278,46 +2846,15 @@ func (inter *Interpreter) NewStructObject(structObjNode *StructNode) *StructObje
 	}
 
 	fields := make(map[string]*Field, len(structObjNode.Fields))
-	methods := make(map[string]*Method, originalStructure.CountMethods())
-
-	method_i := 0
-	for _, fieldDecl := range originalStructure.Fields {
-		if fieldDecl.Func == nil {
-			continue
-		}
-
-		fieldDeclFunc := fieldDecl.Func
-
-		methodFuncClone := new(FuncDec)
-		methodFuncClone.Self = structObject
-		methodFuncClone.Arguments = fieldDeclFunc.Arguments
-		methodFuncClone.ArgumentsDataTypes = fieldDeclFunc.ArgumentsDataTypes
-		methodFuncClone.Body = fieldDeclFunc.Body
-		methodFuncClone.Identifier = fieldDeclFunc.Identifier
-		methodFuncClone.ReturnDataTypes = fieldDeclFunc.ReturnDataTypes
-		methodFuncClone.Template = fieldDeclFunc.Template
-		methodFuncClone.X = fieldDeclFunc.X
-		methodFuncClone.Y = fieldDeclFunc.Y
-
-		cell := &Cell{
-			Scope: inter.CurrentScope,
-		}
-		cell.Set(methodFuncClone, false, structObjNode.X, structObjNode.Y)
-
-		methods[fieldDecl.Identifier] = &Method{
-			Identifier: fieldDecl.Identifier,
-			Func:       cell,
-		}
-		method_i++
-	}
+	methods := inter.StructMethods(originalStructure, structObject, structObjNode.X, structObjNode.Y)
 
 	for _, fieldNode := range structObjNode.Fields {
 		fieldName := fieldNode.Identifier.Value
 		if !originalStructure.CheckField(fieldName) {
-			throw(inter.CurrentFileName, "Attempt to assign a nonexistent field '%s' of structure '%s' while trying to make an instance.", structObjNode.X, structObjNode.Y, fieldName, identifier)
+			inter.throw("Attempt to assign a nonexistent field '%s' of structure '%s' while trying to make an instance.", structObjNode.X, structObjNode.Y, fieldName, identifier)
 		}
 		if originalStructure.IsAFunc(fieldName) {
-			throw(inter.CurrentFileName, "Attempt to assign a value for a method '%s' of structure '%s'.", structObjNode.X, structObjNode.Y, fieldName, identifier)
+			inter.throw("Attempt to assign a value for a method '%s' of structure '%s'.", structObjNode.X, structObjNode.Y, fieldName, identifier)
 		}
 
 		v := inter.GetNodeValueS(fieldNode.Value, fieldNode.Identifier.X, fieldNode.Identifier.Y)
@@ -2331,9 +2868,9 @@ func (inter *Interpreter) NewStructObject(structObjNode *StructNode) *StructObje
 		switch v := v.(type) {
 		case []any:
 			if len(v) > 1 {
-				throw(inter.CurrentFileName, "Field cannot have more than one value.", fieldNode.Identifier.X, fieldNode.Identifier.Y)
+				inter.throw("Field cannot have more than one value.", fieldNode.Identifier.X, fieldNode.Identifier.Y)
 			} else if len(v) == 0 {
-				throw(inter.CurrentFileName, "Cannot assign a field an empty value.", fieldNode.Identifier.X, fieldNode.Identifier.Y)
+				inter.throw("Cannot assign a field an empty value.", fieldNode.Identifier.X, fieldNode.Identifier.Y)
 			}
 
 			cell.InitFromRaw(v[0], originalStructField.DataType, false, structObjNode.X, structObjNode.Y)
@@ -2357,6 +2894,44 @@ func (inter *Interpreter) NewStructObject(structObjNode *StructNode) *StructObje
 	return structObject
 }
 
+// Clones methods of the structure for the instance
+func (inter *Interpreter) StructMethods(originalStructure *Structure, structObject *StructObject, x, y int) map[string]*Method {
+	methods := make(map[string]*Method, originalStructure.CountMethods())
+
+	for _, fieldDecl := range originalStructure.Fields {
+		if fieldDecl.Func == nil {
+			continue
+		}
+
+		fieldDeclFunc := fieldDecl.Func
+
+		methodFuncClone := new(FuncDec)
+		methodFuncClone.Self = structObject
+		methodFuncClone.Arguments = fieldDeclFunc.Arguments
+		methodFuncClone.ArgumentsDataTypes = fieldDeclFunc.ArgumentsDataTypes
+		methodFuncClone.Body = fieldDeclFunc.Body
+		methodFuncClone.Identifier = fieldDeclFunc.Identifier
+		methodFuncClone.ReturnDataTypes = fieldDeclFunc.ReturnDataTypes
+		methodFuncClone.Template = fieldDeclFunc.Template
+		methodFuncClone.Checked = fieldDeclFunc.Checked
+		methodFuncClone.Module = fieldDeclFunc.Module
+		methodFuncClone.X = fieldDeclFunc.X
+		methodFuncClone.Y = fieldDeclFunc.Y
+
+		cell := &Cell{
+			Scope: inter.CurrentScope,
+		}
+		cell.Set(methodFuncClone, false, x, y)
+
+		methods[fieldDecl.Identifier] = &Method{
+			Identifier: fieldDecl.Identifier,
+			Func:       cell,
+		}
+	}
+
+	return methods
+}
+
 func (inter *Interpreter) CookValues(max_i uint, values [][]Node, x, y int) []any {
 	readyValues := make([]any, 0, len(values))
 
@@ -2383,6 +2958,97 @@ func (inter *Interpreter) CookValues(max_i uint, values [][]Node, x, y int) []an
 	return readyValues
 }
 
+// Returns identifiers, values and data types of the pattern targets to add them into a scope
+func (inter *Interpreter) Destructure(pattern *DestructPattern, value any) [][3]any {
+	x, y := pattern.X, pattern.Y
+	bindings := make([][3]any, 0, len(pattern.Targets))
+
+	bind := func(target *DestructTarget, cell *Cell) {
+		value, dataType := any(nil), "any"
+		if cell != nil {
+			value, dataType = cell.Get(), cell.DataType
+		}
+		if target.DataType.Value != "" {
+			dataType = target.DataType.Value
+		}
+
+		bindings = append(bindings, [3]any{target.Identifier.Value, value, dataType})
+	}
+
+	if pattern.Struct {
+		instance, ok := value.(*StructObject)
+		if !ok || instance == nil {
+			inter.throw("Cannot destructure non-instance value '%s' with an instance pattern.", x, y, getValueType(value))
+		}
+
+		for _, target := range pattern.Targets {
+			cell, ok := instance.GetCell(target.Field.Value)
+			if !ok && !target.Optional {
+				inter.throw("Instance of '%s' doesn't have field '%s'.", target.Field.X, target.Field.Y, instance.Identifier, target.Field.Value)
+			}
+
+			bind(target, cell)
+		}
+
+		return bindings
+	}
+
+	table, ok := value.(*Map)
+	if !ok {
+		inter.throw("Cannot destructure non-table value '%s' with a table pattern.", x, y, getValueType(value))
+	}
+
+	usedKeys := make(map[any]bool, len(pattern.Targets))
+	position := int64(0)
+
+	for _, target := range pattern.Targets {
+		if target.Rest {
+			rest := newMap(table.DataType, table.Len()-len(usedKeys))
+
+			for key, cell := range table.AllFromFront() {
+				if usedKeys[key] {
+					continue
+				}
+				if position >= 0 {
+					key = int64(rest.Len())
+				}
+
+				rest.Set(key, CLPTR(inter.CurrentScope, rest.DataType, cell.Get(), x, y))
+			}
+			rest.ToMemory()
+
+			bindings = append(bindings, [3]any{target.Identifier.Value, rest, "table"})
+			break
+		}
+
+		var key any
+		if target.Key != nil {
+			key = inter.GetNodeValueS(target.Key, x, y)
+			switch k := key.(type) {
+			case rawint64:
+				key = int64(k)
+			case rawuint64:
+				key = uint64(k)
+			}
+
+			position = -1
+		} else {
+			key = position
+			position++
+		}
+		usedKeys[key] = true
+
+		cell, ok := table.Get(key)
+		if !ok && !target.Optional {
+			inter.throw("Table doesn't have an element with key '%s'.", target.Identifier.X, target.Identifier.Y, format(key))
+		}
+
+		bind(target, cell)
+	}
+
+	return bindings
+}
+
 type ReturnNil struct{}
 
 func (inter *Interpreter) CompleteNode(node Node) (end, skip bool, value []any) {
@@ -2390,10 +3056,13 @@ func (inter *Interpreter) CompleteNode(node Node) (end, skip bool, value []any)
 	switch node := node.(type) {
 	case *FuncDec:
 		if len(node.Identifier.Value) == 0 {
-			throw(inter.CurrentFileName, "Name of the function cannot be empty.", node.X, node.Y)
+			inter.throw("Name of the function cannot be empty.", node.X, node.Y)
+		}
+		if inter.CurrentScope.MainScope {
+			node.Scope = inter.CurrentScope
 		}
 		if !inter.CurrentScope.Add(node.Identifier.Value, node, "func", node.X, node.Y) {
-			throw(inter.CurrentFileName, "Attempt to redeclare a variable '%s'.", node.X, node.Y, node.Identifier.Value)
+			inter.throw("Attempt to redeclare a variable '%s'.", node.X, node.Y, node.Identifier.Value)
 		}
 	case *StructDeclNode:
 		inter.DeclareStructure(node)
@@ -2401,9 +3070,9 @@ func (inter *Interpreter) CompleteNode(node Node) (end, skip bool, value []any)
 		readyValues := inter.CookValues(uint(len(node.Identifier)), node.Value, node.X, node.Y)
 
 		if len(readyValues) > len(node.Identifier) && !node.Argument {
-			throw(inter.CurrentFileName, "Too many values(%d) for %d identifier(s).", node.X, node.Y, len(readyValues), len(node.Identifier))
+			inter.throw("Too many values(%d) for %d identifier(s).", node.X, node.Y, len(readyValues), len(node.Identifier))
 		} else if len(readyValues) > len(node.Identifier) && node.Argument {
-			throw(inter.CurrentFileName, "Attempt to use multiple values as a single argument.", node.X, node.Y)
+			inter.throw("Attempt to use multiple values as a single argument.", node.X, node.Y)
 		}
 
 		for i, ident := range node.Identifier {
@@ -2412,21 +3081,35 @@ func (inter *Interpreter) CompleteNode(node Node) (end, skip bool, value []any)
 			}
 
 			if !inter.CurrentScope.Add(ident.Value, readyValues[i], node.DataTypes[i].Value, node.X, node.Y) {
-				throw(inter.CurrentFileName, "Attempt to redeclare a variable '%s'.", node.X, node.Y, ident.Value)
+				inter.throw("Attempt to redeclare a variable '%s'.", node.X, node.Y, ident.Value)
+			}
+		}
+	case *DestructDec:
+		value := inter.GetNodeValueS(node.Value, node.X, node.Y)
+		if values, ok := value.([]any); ok {
+			if len(values) != 1 {
+				inter.throw("Cannot destructure %d values at the same time.", node.X, node.Y, len(values))
+			}
+			value = values[0]
+		}
+
+		for _, binding := range inter.Destructure(node.Pattern, value) {
+			if !inter.CurrentScope.Add(binding[0], binding[1], binding[2].(string), node.X, node.Y) {
+				inter.throw("Attempt to redeclare a variable '%s'.", node.X, node.Y, binding[0])
 			}
 		}
 	case *SetVar:
 		readyValues := inter.CookValues(uint(len(node.Value)), node.Value, node.X, node.Y)
 
 		if len(readyValues) > len(node.Var) {
-			throw(inter.CurrentFileName, "Too many values in assignment", node.X, node.Y)
+			inter.throw("Too many values in assignment", node.X, node.Y)
 		} else if len(readyValues) < len(node.Var) {
-			throw(inter.CurrentFileName, "Too few values in assignment", node.X, node.Y)
+			inter.throw("Too few values in assignment", node.X, node.Y)
 		}
 
 		for i, ident := range node.Var {
 			if !inter.CurrentScope.Set(ident.Value, readyValues[i], node.X, node.Y) {
-				throw(inter.CurrentFileName, "Attempt to assign value to non-existing variable '%s'.", node.X, node.Y, node.Value)
+				inter.throw("Attempt to assign value to non-existing variable '%s'.", node.X, node.Y, node.Value)
 			}
 		}
 	case *IndirAssignNode:
@@ -2434,29 +3117,39 @@ func (inter *Interpreter) CompleteNode(node Node) (end, skip bool, value []any)
 
 		valuePointer, ok := valuePointerInterface.(uintptr)
 		if !ok {
-			throw(inter.CurrentFileName, "Attempt to do indirect assignment with invalid pointer.", node.X, node.Y)
+			inter.throw("Attempt to do indirect assignment with invalid pointer.", node.X, node.Y)
 		}
 
 		pointerCell := scope.GetCellWithAddress(unsafe.Pointer(valuePointer))
 		if pointerCell == nil {
-			throw(inter.CurrentFileName, "Attempt to do indirect assignment of non-existing pointer.", node.X, node.Y)
+			inter.throw("Attempt to do indirect assignment of non-existing pointer.", node.X, node.Y)
 		}
 
 		valuePtr, ok := pointerCell.Get().(uintptr)
 		if !ok {
-			throw(inter.CurrentFileName, "Attempt to do indirect assignment with non-pointer value.", node.X, node.Y)
+			inter.throw("Attempt to do indirect assignment with non-pointer value.", node.X, node.Y)
 		}
 
 		cellOfPtr := scope.GetCellWithAddress(unsafe.Pointer(valuePtr))
 		if cellOfPtr == nil {
-			throw(inter.CurrentFileName, "Attempt to do indirect assignment of non-existing value.", node.X, node.Y)
+			inter.throw("Attempt to do indirect assignment of non-existing value.", node.X, node.Y)
 		}
 
 		newValue := inter.GetNodeValueS(node.Value, node.X, node.Y)
 
-		cellOfPtr.Set(newValue, false, node.X, node.Y)
+		cellOfPtr.Store(newValue, node.X, node.Y)
 	case *FuncCall:
 		inter.GetNodeValue(node)
+	case *SpawnNode:
+		inter.Spawn(node)
+	case *DeferNode:
+		call, funcDec, args := inter.PrepareCall(node.Call, "Defer", node.X, node.Y)
+
+		inter.CurrentScope.Defer(&DeferredCall{funcDec, args, call.X, call.Y})
+	case *SelectStmt:
+		return inter.Select(node)
+	case *WithNode:
+		return inter.With(node)
 	case *SetElem:
 		inter.SetElementValue(node)
 	case *SetFieldNode:
@@ -2489,11 +3182,11 @@ func (inter *Interpreter) CompleteNode(node Node) (end, skip bool, value []any)
 		return true, false, readyValues
 	case *ExternalImport:
 		if inter.UnableToImport {
-			throw(inter.CurrentFileName, "External import keyword must be at the beggining of the code.", node.Position(), node.Line())
+			inter.throw("External import keyword must be at the beggining of the code.", node.Position(), node.Line())
 		}
 		scope := inter.CurrentScope
 		if !scope.MainScope {
-			throw(inter.CurrentFileName, "Cannot use external import keyword outside main scope.", node.Position(), node.Line())
+			inter.throw("Cannot use external import keyword outside main scope.", node.Position(), node.Line())
 		}
 
 		path := node.Path.Value
@@ -2503,28 +3196,28 @@ func (inter *Interpreter) CompleteNode(node Node) (end, skip bool, value []any)
 			loadLibraryIntoScope(inter.CurrentFileName, path, node, scope)
 
 		} else {
-			throw(inter.CurrentFileName, "Cannot perform external import without a library path.", node.Position(), node.Line())
+			inter.throw("Cannot perform external import without a library path.", node.Position(), node.Line())
 		}
 		return false, false, nil
 	case *Import:
 		if inter.UnableToImport {
-			throw(inter.CurrentFileName, "Import keyword must be at the beggining of the code.", node.Position(), node.Line())
+			inter.throw("Import keyword must be at the beggining of the code.", node.Position(), node.Line())
 		}
 		if !inter.CurrentScope.MainScope {
-			throw(inter.CurrentFileName, "Cannot use import keyword outside main scope.", node.Position(), node.Line())
+			inter.throw("Cannot use import keyword outside main scope.", node.Position(), node.Line())
 		}
 
 		if len(node.Path) > 0 && len(node.Path) < 2 {
 			path, ok := node.Path[0].(*StrNode)
 			if !ok {
-				throw(inter.CurrentFileName, "Path for the import keyword cannot be a non-string value.", node.Position(), node.Line())
+				inter.throw("Path for the import keyword cannot be a non-string value.", node.Position(), node.Line())
 			}
 
-			importModule(path.Value, inter.CurrentScope, node.X, node.Y)
+			importModule(node, path.Value, inter.CurrentScope)
 		} else if len(node.Path) > 1 {
-			throw(inter.CurrentFileName, "Cannot import more than one file or module.", node.Position(), node.Line())
+			inter.throw("Cannot import more than one file or module.", node.Position(), node.Line())
 		} else {
-			throw(inter.CurrentFileName, "Cannot import the file or the module without a path.", node.Position(), node.Line())
+			inter.throw("Cannot import the file or the module without a path.", node.Position(), node.Line())
 		}
 		return false, false, nil
 	case *WhileNode:
@@ -2538,12 +3231,55 @@ func (inter *Interpreter) CompleteNode(node Node) (end, skip bool, value []any)
 		}
 	case *ForeachNode:
 		cycleValue := inter.GetNodeValueS(node.CycleValue, node.X, node.Y)
+		if values, ok := cycleValue.([]any); ok {
+			if len(values) != 1 {
+				inter.throw("Cannot iterate over %d values at the same time.", node.X, node.Y, len(values))
+			}
+			cycleValue = values[0]
+		}
 		keyIdent, valueIdent := node.KeyIdent, node.ValueIdent
 
 		switch cycleValue := cycleValue.(type) {
 		case *Map:
-			for key, value := range cycleValue.AllFromFront() {
-				end, skip, returnValue := inter.CompleteBody(node.Body, false, true, [3]any{keyIdent.Value, key, getValueType(key)}, [3]any{valueIdent.Value, value.Get(), value.DataType})
+			//Body runs over a snapshot, so it may change the table and other tasks don't race with the iteration
+			keys, cells := tableEntries(cycleValue)
+			for i, key := range keys {
+				value := cells[i]
+				addToScope := [][3]any{{keyIdent.Value, key, getValueType(key)}}
+				if node.ValuePattern != nil {
+					addToScope = append(addToScope, inter.Destructure(node.ValuePattern, value.Get())...)
+				} else {
+					addToScope = append(addToScope, [3]any{valueIdent.Value, value.Get(), value.DataType})
+				}
+
+				end, skip, returnValue := inter.CompleteBody(node.Body, false, true, addToScope...)
+
+				if skip {
+					continue
+				} else if end || returnValue != nil {
+					return end, skip, returnValue
+				}
+			}
+		case *FuncDec:
+			//Iterator functions return the next value and whether there is one, like lines of a stream
+			for i := int64(0); ; i++ {
+				results := inter.CallFunctionValue(cycleValue, node.X, node.Y)
+				if len(results) != 2 {
+					inter.throw("Iterator function must return a value and whether it exists.", node.X, node.Y)
+				}
+				if ok, _ := results[1].(bool); !ok {
+					break
+				}
+
+				value := results[0]
+				addToScope := [][3]any{{keyIdent.Value, i, "i64"}}
+				if node.ValuePattern != nil {
+					addToScope = append(addToScope, inter.Destructure(node.ValuePattern, value)...)
+				} else {
+					addToScope = append(addToScope, [3]any{valueIdent.Value, value, getValueType(value)})
+				}
+
+				end, skip, returnValue := inter.CompleteBody(node.Body, false, true, addToScope...)
 
 				if skip {
 					continue
@@ -2552,17 +3288,17 @@ func (inter *Interpreter) CompleteNode(node Node) (end, skip bool, value []any)
 				}
 			}
 		default:
-			throw(inter.CurrentFileName, "Unable to iterate over a non-table value.", node.X, node.Y)
+			inter.throw("Unable to iterate over a value that is neither a table nor an iterator function.", node.X, node.Y)
 		}
 	default:
 		//fmt.Printf("%T",node.(*BinOpNode).L)
-		throw(inter.CurrentFileName, "Invalid node '%s'.", node.Position(), node.Line(), getInterfaceType(node))
+		inter.throw("Invalid node '%s'.", node.Position(), node.Line(), getInterfaceType(node))
 	}
 	inter.UnableToImport = true
 	return false, false, nil
 }
 
-func (inter *Interpreter) Complete(logenv bool) map[any]*Cell { //go run yks run test.yks
+func (inter *Interpreter) Complete(logenv bool) *Module { //go run yks run test.yks
 	go func() {
 		runtime.LockOSThread()
 		defer runtime.UnlockOSThread()
@@ -2590,11 +3326,12 @@ func (inter *Interpreter) Complete(logenv bool) map[any]*Cell { //go run yks run
 	for _, node := range inter.AST {
 		inter.CompleteNode(node)
 	}
+	inter.CompleteDeferred(mainScope)
 
 	if logenv {
 		fmt.Println(mainScope.Data)
 	}
 	clear(mainScope.Pointers)
 
-	return mainScope.Data
+	return NewModule(inter, mainScope)
 }
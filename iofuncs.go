@@ -0,0 +1,261 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"maps"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Descriptors of the standard streams used by the io builtins
+const (
+	stdinFd int64 = iota
+	stdoutFd
+	stderrFd
+)
+
+var (
+	stdin  = bufio.NewReader(os.Stdin)
+	stdout = bufio.NewWriterSize(os.Stdout, 64*1024)
+
+	stdoutMutex sync.Mutex
+	//Output to a terminal is flushed after every write, so prompts and progress are visible right away
+	stdoutIsTerminal = isTerminal(os.Stdout)
+
+	//Other streams, like pipes of processes, referenced by descriptors that follow the standard ones
+	streams      = make(map[int64]*Stream)
+	streamsMutex sync.Mutex
+	nextFd       = stderrFd + 1
+)
+
+// Readable or writable end of a pipe
+type Stream struct {
+	Reader *bufio.Reader
+	Writer io.Writer
+	Closer io.Closer
+}
+
+var (
+	ioFuncs = map[string]func(v ...any) []any{
+		"stream_write": func(v ...any) []any {
+			argsCheck(v, 2, 2, "int", "any")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			switch fd := toInt64(v[0]); fd {
+			case stdoutFd:
+				writeStdout(format(v[1]))
+			case stderrFd:
+				flushStdout()
+				os.Stderr.WriteString(format(v[1]))
+			default:
+				stream := inter.stream(fd, x, y)
+				if stream.Writer == nil {
+					inter.throw("Stream is not writable.", x, y)
+				}
+
+				if _, err := io.WriteString(stream.Writer, format(v[1])); err != nil {
+					return []any{err}
+				}
+			}
+
+			return []any{nil}
+		},
+
+		"stream_flush": func(v ...any) []any {
+			argsCheck(v, 1, 1, "int")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			if toInt64(v[0]) == stdoutFd {
+				flushStdout()
+			}
+
+			return nil
+		},
+
+		"stream_readline": func(v ...any) []any {
+			argsCheck(v, 1, 1, "int")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			line, ok := readLine(inter.streamReader(toInt64(v[0]), x, y))
+
+			return []any{line, ok}
+		},
+
+		"stream_readall": func(v ...any) []any {
+			argsCheck(v, 1, 1, "int")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			reader := inter.streamReader(toInt64(v[0]), x, y)
+			flushStdout()
+
+			content, err := io.ReadAll(reader)
+			if err != nil {
+				inter.throw("Unable to read the stream: %s.", x, y, err.Error())
+			}
+
+			return []any{string(content)}
+		},
+
+		//Iterator for foreach, every call reads the next line so the stream is never read ahead
+		"stream_lines": func(v ...any) []any {
+			argsCheck(v, 1, 1, "int")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			reader := inter.streamReader(toInt64(v[0]), x, y)
+
+			return []any{newFTemp("lines", func(v ...any) []any {
+				line, ok := readLine(reader)
+
+				return []any{line, ok}
+			})}
+		},
+
+		"input": func(v ...any) []any {
+			argsCheck(v, 0, 1, "any")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			if len(v) > 0 {
+				writeStdout(format(v[0]))
+			}
+
+			line, _ := readLine(stdin)
+
+			return []any{line}
+		},
+
+		"stream_close": func(v ...any) []any {
+			argsCheck(v, 1, 1, "int")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			//Standard streams stay open for the whole program
+			fd := toInt64(v[0])
+			if fd <= stderrFd {
+				return []any{nil}
+			}
+
+			stream := inter.stream(fd, x, y)
+
+			streamsMutex.Lock()
+			delete(streams, fd)
+			streamsMutex.Unlock()
+
+			if stream.Closer == nil {
+				return []any{nil}
+			}
+
+			return []any{stream.Closer.Close()}
+		},
+	}
+)
+
+func init() {
+	maps.Copy(builtinFuncs, ioFuncs)
+}
+
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Writes into the buffered stdout
+func writeStdout(s string) {
+	stdoutMutex.Lock()
+	defer stdoutMutex.Unlock()
+
+	stdout.WriteString(s)
+	if stdoutIsTerminal {
+		stdout.Flush()
+	}
+}
+
+// Must be called before the program exits or reads stdin, so the output is not lost or out of order
+func flushStdout() {
+	stdoutMutex.Lock()
+	defer stdoutMutex.Unlock()
+
+	stdout.Flush()
+}
+
+// Reads the line without the line ending, ok is false at the end of the input
+func readLine(reader *bufio.Reader) (string, bool) {
+	flushStdout()
+
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		return "", false
+	}
+
+	line = strings.TrimSuffix(line, "\n")
+
+	return strings.TrimSuffix(line, "\r"), true
+}
+
+// Reserves the descriptor for a stream or a file
+func newFd() int64 {
+	streamsMutex.Lock()
+	defer streamsMutex.Unlock()
+
+	fd := nextFd
+	nextFd++
+
+	return fd
+}
+
+func registerStream(stream *Stream) int64 {
+	fd := newFd()
+
+	streamsMutex.Lock()
+	defer streamsMutex.Unlock()
+
+	streams[fd] = stream
+
+	return fd
+}
+
+func (inter *Interpreter) stream(fd int64, x, y int) *Stream {
+	streamsMutex.Lock()
+	defer streamsMutex.Unlock()
+
+	stream, ok := streams[fd]
+	if !ok {
+		inter.throw("Attempt to use a closed or non-existing stream.", x, y)
+	}
+
+	return stream
+}
+
+func (inter *Interpreter) streamReader(fd int64, x, y int) *bufio.Reader {
+	if fd == stdinFd {
+		return stdin
+	}
+
+	var reader *bufio.Reader
+	if fd > stderrFd {
+		reader = inter.stream(fd, x, y).Reader
+	}
+	if reader == nil {
+		inter.throw("Stream is not readable.", x, y)
+	}
+
+	return reader
+}
@@ -0,0 +1,383 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	jsonFuncs = map[string]func(v ...any) []any{
+		"json_encode": func(v ...any) []any {
+			argsCheck(v, 1, 2, "any", "any")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			var buf bytes.Buffer
+			if err := inter.encodeJSON(&buf, v[0]); err != nil {
+				return []any{"", err}
+			}
+
+			//Indent is a count of spaces or the indentation string itself
+			var indent string
+			if len(v) > 1 {
+				switch i := v[1].(type) {
+				case nil:
+				case string:
+					indent = i
+				default:
+					if !checkDataType("int", i) {
+						inter.throw("Invalid argument #%d. Expected %s.", x, y, 2, "int or string")
+					}
+					indent = strings.Repeat(" ", int(max(toInt64(i), 0)))
+				}
+			}
+
+			if indent == "" {
+				return []any{buf.String(), nil}
+			}
+
+			var indented bytes.Buffer
+			if err := json.Indent(&indented, buf.Bytes(), "", indent); err != nil {
+				return []any{"", err}
+			}
+
+			return []any{indented.String(), nil}
+		},
+
+		"json_decode": func(v ...any) []any {
+			argsCheck(v, 1, 1, "string")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			value, err := inter.decodeJSON(v[0].(string), x, y)
+
+			return []any{value, err}
+		},
+
+		"json_decode_into": func(v ...any) []any {
+			argsCheck(v, 2, 2, "string", "any")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			structure, ok := v[1].(*Structure)
+			if !ok {
+				inter.throw("Invalid argument #%d. Expected %s.", x, y, 2, "struct")
+			}
+
+			value, err := inter.decodeJSON(v[0].(string), x, y)
+			if err != nil {
+				return []any{nil, err}
+			}
+
+			structObject, err := inter.jsonToStruct(value, structure, x, y)
+			if err != nil {
+				return []any{nil, err}
+			}
+
+			return []any{structObject, nil}
+		},
+	}
+)
+
+func init() {
+	maps.Copy(builtinFuncs, jsonFuncs)
+}
+
+// Tables with keys from 0 to len-1 become arrays, other tables and instances become objects
+func (inter *Interpreter) encodeJSON(buf *bytes.Buffer, value any) error {
+	switch value := value.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(value))
+	case string:
+		writeJSONString(buf, value)
+	case error:
+		writeJSONString(buf, value.Error())
+	case float64, float32:
+		f := mustNTOF64(value)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return fmt.Errorf("unable to encode %s to JSON", format(value))
+		}
+
+		bits := 64
+		if checkType[float32](value) {
+			bits = 32
+		}
+		buf.WriteString(strconv.FormatFloat(f, 'g', -1, bits))
+	case *big.Int, *Decimal:
+		buf.WriteString(format(value))
+	case *Map:
+		if value.Len() > 0 && value.IsSequence() {
+			buf.WriteByte('[')
+			i := 0
+			for _, cell := range value.AllFromFront() {
+				if i > 0 {
+					buf.WriteByte(',')
+				}
+				if err := inter.encodeJSON(buf, cell.Get()); err != nil {
+					return err
+				}
+				i++
+			}
+			buf.WriteByte(']')
+
+			return nil
+		}
+
+		buf.WriteByte('{')
+		i := 0
+		for key, cell := range value.AllFromFront() {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeJSONString(buf, format(key))
+			buf.WriteByte(':')
+			if err := inter.encodeJSON(buf, cell.Get()); err != nil {
+				return err
+			}
+			i++
+		}
+		buf.WriteByte('}')
+	case *StructObject:
+		if value == nil {
+			buf.WriteString("null")
+			return nil
+		}
+
+		buf.WriteByte('{')
+		for i, name := range inter.structFieldsOrder(value) {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeJSONString(buf, name)
+			buf.WriteByte(':')
+			if err := inter.encodeJSON(buf, value.Fields[name].Value.Get()); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		switch {
+		case checkDataType("uint", value):
+			buf.WriteString(strconv.FormatUint(toUint64(value), 10))
+		case checkDataType("int", value):
+			buf.WriteString(strconv.FormatInt(toInt64(value), 10))
+		default:
+			return fmt.Errorf("unable to encode '%s' value to JSON", getValueType(value))
+		}
+	}
+
+	return nil
+}
+
+func writeJSONString(buf *bytes.Buffer, s string) {
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(s)
+
+	//Encoder ends every value with a newline
+	buf.Truncate(buf.Len() - 1)
+}
+
+// Fields of the instance in the order of the structure declaration, or sorted if the structure isn't visible
+func (inter *Interpreter) structFieldsOrder(structObject *StructObject) []string {
+	if structure, ok := inter.findStructure(structObject.Identifier); ok {
+		names := make([]string, 0, len(structObject.Fields))
+		for _, field := range structure.Fields {
+			if _, ok := structObject.Fields[field.Identifier]; ok {
+				names = append(names, field.Identifier)
+			}
+		}
+
+		return names
+	}
+
+	return slices.Sorted(maps.Keys(structObject.Fields))
+}
+
+// Decodes the document into ordered tables of 'any' type, integer numbers become i64 or u64 and others f64
+func (inter *Interpreter) decodeJSON(data string, x, y int) (any, error) {
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+
+	value, err := inter.decodeJSONValue(decoder, x, y)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid character after top-level value")
+	}
+
+	return value, nil
+}
+
+func (inter *Interpreter) decodeJSONValue(decoder *json.Decoder, x, y int) (any, error) {
+	token, err := decoder.Token()
+	if err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	} else if err != nil {
+		return nil, err
+	}
+
+	switch token := token.(type) {
+	case json.Delim:
+		table := newMap("any", 0)
+
+		for i := int64(0); decoder.More(); i++ {
+			var key any = i
+			if token == '{' {
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				key = keyToken.(string)
+			}
+
+			value, err := inter.decodeJSONValue(decoder, x, y)
+			if err != nil {
+				return nil, err
+			}
+
+			table.Set(key, CLPTR(inter.CurrentScope, table.DataType, value, x, y))
+		}
+		table.ToMemory()
+
+		//Closing delimiter
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+
+		return table, nil
+	case json.Number:
+		if n, err := strconv.ParseInt(token.String(), 10, 64); err == nil {
+			return n, nil
+		}
+		if n, err := strconv.ParseUint(token.String(), 10, 64); err == nil {
+			return n, nil
+		}
+
+		return token.Float64()
+	}
+
+	return token, nil
+}
+
+// Makes an instance of the structure from the decoded object, values must match the declared types of the fields
+func (inter *Interpreter) jsonToStruct(value any, structure *Structure, x, y int) (*StructObject, error) {
+	object, ok := value.(*Map)
+	if !ok || (object.Len() > 0 && object.IsSequence()) {
+		return nil, fmt.Errorf("expected an object for '%s'", structure.Identifier)
+	}
+
+	structObject := &StructObject{
+		Identifier: structure.Identifier,
+	}
+
+	fields := make(map[string]*Field, object.Len())
+	for key, cell := range object.AllFromFront() {
+		name := format(key)
+
+		fieldDecl := structure.GetField(name)
+		if fieldDecl == nil || fieldDecl.Func != nil {
+			return nil, fmt.Errorf("structure '%s' doesn't have field '%s'", structure.Identifier, name)
+		}
+
+		//Null leaves the field unset
+		fieldValue := cell.Get()
+		if fieldValue == nil {
+			continue
+		}
+
+		fieldValue, err := inter.jsonToField(fieldValue, fieldDecl.DataType, x, y)
+		if err != nil {
+			return nil, fmt.Errorf("field '%s' of '%s': %s", name, structure.Identifier, err.Error())
+		}
+
+		fieldCell := &Cell{
+			Scope: inter.CurrentScope,
+		}
+		fieldCell.InitFromRaw(fieldValue, fieldDecl.DataType, false, x, y)
+
+		fields[name] = &Field{
+			Identifier: name,
+			DataType:   fieldCell.DataType,
+			Value:      fieldCell,
+		}
+	}
+
+	structObject.Fields = fields
+	structObject.Methods = inter.StructMethods(structure, structObject, x, y)
+	structObject.ToMemoryLayout(structObject.Layout())
+
+	structObject.IsDirty = true
+
+	return structObject, nil
+}
+
+func (inter *Interpreter) jsonToField(value any, dataType string, x, y int) (any, error) {
+	mismatch := fmt.Errorf("expected '%s' got '%s'", dataType, getValueType(value))
+
+	switch dataType {
+	case "any":
+		return value, nil
+	case "string", "bool", "table":
+		if getValueType(value) != dataType {
+			return nil, mismatch
+		}
+
+		return value, nil
+	case "i64", "i32", "i16", "i8", "u64", "u32", "u16", "u8":
+		if !checkDataType("int", value) {
+			return nil, mismatch
+		}
+		if !fitsType(value, dataType) {
+			return nil, fmt.Errorf("value %s doesn't fit in '%s'", format(value), dataType)
+		}
+
+		converted, _ := assertType(value, dataType)
+
+		return converted, nil
+	case "f64", "f32", "bigint", "decimal":
+		if !checkDataType("number", value) {
+			return nil, mismatch
+		}
+
+		converted, _ := assertType(value, dataType)
+
+		return converted, nil
+	}
+
+	//Nested structure
+	if structure, ok := inter.findStructure(dataType); ok {
+		return inter.jsonToStruct(value, structure, x, y)
+	}
+
+	return nil, fmt.Errorf("unable to decode into '%s'", dataType)
+}
+
+func (inter *Interpreter) findStructure(name string) (*Structure, bool) {
+	cell := inter.CurrentScope.GetCell(name)
+	if cell == nil {
+		return nil, false
+	}
+
+	structure, ok := cell.Load().(*Structure)
+
+	return structure, ok
+}
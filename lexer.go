@@ -4,6 +4,7 @@ import (
 	//"fmt"
 
 	"errors"
+	"math/big"
 	"strconv"
 	"strings"
 	"unicode"
@@ -36,10 +37,16 @@ var (
 		"import":   "import",
 		"new":      "newstruct",
 		"struct":   "struct",
+		"spawn":    "spawn",
+		"defer":    "defer",
+		"select":   "select",
+		"case":     "case",
+		"with":     "with",
 
 		"<-": "table_datatypes_init",
 
 		"@external": "external_import",
+		"@checked":  "checked_attr",
 
 		"?": "asserttype",
 
@@ -54,10 +61,12 @@ var (
 		"-": "sub",
 		"/": "div",
 		"*": "mul",
+		"%": "mod",
 		"|": "bitor",
 		"&": "getptr",
 
-		".": "indexstruct",
+		".":   "indexstruct",
+		"...": "rest",
 
 		"(": "openbracket",
 		")": "closebracket",
@@ -80,6 +89,7 @@ var (
 		"<":  "less",
 		">=": "greatereq",
 		"<=": "lesseq",
+		"is": "is",
 
 		//stmt operators
 		"&&": "and",
@@ -213,6 +223,13 @@ func (lexer *Lexer) GetTokens() []Token {
 	lexer.CurrentColumn = 0
 	lexer.Next()
 
+	//Shebang line of executable scripts
+	if strings.HasPrefix(lexer.Source, "#!") {
+		for lexer.CurrentPosition >= 0 && lexer.Str() != "\n" {
+			lexer.Next()
+		}
+	}
+
 	tokens := []Token{}
 
 MAIN_LOOP:
@@ -405,6 +422,30 @@ func (lexer *Lexer) GetNumber() Token {
 		lexer.Next()
 	}
 
+	//n suffix makes a bigint literal, d suffix makes a decimal literal
+	if lexer.CurrentPosition >= 0 && (lexer.Str() == "n" || !hexademical && lexer.Str() == "d") {
+		if next := lexer.PeekNext(); !unicode.IsLetter(next) && !unicode.IsDigit(next) && next != '_' {
+			suffix := lexer.Str()
+			lexer.Next()
+
+			if suffix == "d" {
+				n, ok := ParseDecimal(number)
+				if !ok {
+					throw(lexer.CurrentFileName, "Invalid decimal syntax: '%s'", lexer.CurrentColumn, lexer.CurrentLine, number)
+				}
+
+				return NewToken(n, "float", lexer.CurrentPosition, lexer.CurrentLine)
+			}
+
+			n, ok := new(big.Int).SetString(number, 0)
+			if !ok {
+				throw(lexer.CurrentFileName, "Invalid bigint syntax: '%s'", lexer.CurrentColumn, lexer.CurrentLine, number)
+			}
+
+			return NewToken(n, "int", lexer.CurrentPosition, lexer.CurrentLine)
+		}
+	}
+
 	if dots > 0 {
 		n, err := strToFloat(number)
 		if errors.Is(err, strconv.ErrSyntax) {
@@ -421,7 +462,12 @@ func (lexer *Lexer) GetNumber() Token {
 		} else if err != nil {
 			n, err := strToUint(number)
 			if err != nil {
-				throw(lexer.CurrentFileName, "Integer value out of range syntax: '%s'", lexer.CurrentColumn, lexer.CurrentLine, number)
+				bigN, ok := new(big.Int).SetString(number, 0)
+				if !ok {
+					throw(lexer.CurrentFileName, "Integer value out of range syntax: '%s'", lexer.CurrentColumn, lexer.CurrentLine, number)
+				}
+
+				return NewToken(bigN, "int", lexer.CurrentPosition, lexer.CurrentLine)
 			}
 
 			return NewToken(rawuint64(n), "int", lexer.CurrentPosition, lexer.CurrentLine)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 const (
@@ -21,4 +22,47 @@ var ( //go run yks run test.yks
 	libs     = filepath.Join(getParentPath(getParentPath(getSelfPath())), "src")
 
 	println = fmt.Println
+
+	strictMode  bool //Disables numeric promotion in binary operations
+	checkedMode bool //Integer overflows are errors in the whole program
+	runFlags    = map[string]*bool{
+		"--strict":  &strictMode,
+		"--checked": &checkedMode,
+	}
+
+	libPaths      []string //Directories from --lib-path flags, searched before YKS_PATH
+	scriptArgs    []string //Arguments after the path of the script
+	libPathFlag   = "--lib-path"
+	libPathEnvVar = "YKS_PATH"
 )
+
+// Turns on the run flags found before the path of the script and returns the rest of arguments
+func cutRunFlags(args []string) []string {
+	rest := []string{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "--") {
+			return append(rest, args[i:]...)
+		}
+
+		if value, ok := strings.CutPrefix(arg, libPathFlag+"="); ok {
+			libPaths = append(libPaths, filepath.SplitList(value)...)
+			continue
+		}
+		if arg == libPathFlag && i+1 < len(args) {
+			libPaths = append(libPaths, filepath.SplitList(args[i+1])...)
+			i++
+			continue
+		}
+
+		flag, ok := runFlags[arg]
+		if !ok {
+			rest = append(rest, arg)
+			continue
+		}
+
+		*flag = true
+	}
+
+	return rest
+}
@@ -3,11 +3,13 @@ package main
 import (
 	"fmt"
 	"math"
+	"math/big"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"unsafe"
 )
 
@@ -32,9 +34,9 @@ func argsCheck(v []any, min, max int, expectedDataTypes ...string) {
 	inter := v[2].(*Interpreter)
 
 	if len(v) < min+3 {
-		throw(inter.CurrentFileName, "Attempt to pass less arguments to a function call than function actually need, minimum is %d.", x, y, min)
+		inter.throw("Attempt to pass less arguments to a function call than function actually need, minimum is %d.", x, y, min)
 	} else if len(v) > max+3 {
-		throw(inter.CurrentFileName, "Attempt to pass more arguments to a function call than function actually need, maximum is %d.", x, y, max)
+		inter.throw("Attempt to pass more arguments to a function call than function actually need, maximum is %d.", x, y, max)
 	} else {
 		args := v[3:]
 
@@ -44,7 +46,7 @@ func argsCheck(v []any, min, max int, expectedDataTypes ...string) {
 			argument := args[i]
 
 			if !checkDataType(expectedDataType, argument) {
-				throw(inter.CurrentFileName, "Invalid argument #%d. Expected %s.", x, y, i+1, expectedDataType)
+				inter.throw("Invalid argument #%d. Expected %s.", x, y, i+1, expectedDataType)
 			}
 		}
 	}
@@ -87,38 +89,82 @@ func mustNTOF64(n any) float64 {
 	switch n := n.(type) {
 	case float64:
 		return n
-	case int64:
+	case float32:
 		return float64(n)
+	case int64, int32, int, int16, int8, rawint64:
+		return float64(toInt64(n))
+	case uint8, uint16, uint, uint32, uint64:
+		return float64(toUint64(n))
+	case *big.Int:
+		f, _ := new(big.Float).SetInt(n).Float64()
+		return f
+	case *Decimal:
+		f, _ := strconv.ParseFloat(n.String(), 64)
+		return f
 	}
 	return 0
 }
 
 func getValueType(v any) string {
-	switch v.(type) {
+	switch v := v.(type) {
 	case nil:
 		return "void"
 	case string:
 		return "string"
+	case float32:
+		return "f32"
 	case float64:
-		return "float"
-	case int64:
-		return "int"
+		return "f64"
+	case int64, rawint64:
+		return "i64"
+	case int32:
+		return "i32"
+	case int16:
+		return "i16"
+	case int8:
+		return "i8"
+	case uint64, rawuint64:
+		return "u64"
+	case uint32:
+		return "u32"
+	case uint16:
+		return "u16"
+	case uint8:
+		return "u8"
 	case bool:
 		return "bool"
 	case *Map:
 		return "table"
 	case *StructObject:
-		return "instance"
+		return v.Identifier
 	case *Structure:
-		return "structure"
+		return "struct"
 	case *FuncDec:
 		return "func"
-	case uintptr, unsafe.Pointer:
+	case uintptr:
 		return "pointer"
+	case unsafe.Pointer:
+		return "unsafe.pointer"
 	case error:
 		return "error"
+	case *big.Int:
+		return "bigint"
+	case *Decimal:
+		return "decimal"
+	case *Task:
+		return "task"
+	case *Channel:
+		return "chan"
+	case *sync.Mutex:
+		return "mutex"
+	case *sync.WaitGroup:
+		return "waitgroup"
+	case *RandomGenerator:
+		return "random"
+	case *Module:
+		return "module"
 	}
-	return "any"
+	return "unknown"
 }
 
 func checkDataType(expected string, v any) bool {
@@ -138,17 +184,42 @@ func checkDataType(expected string, v any) bool {
 		return false
 	case "number":
 		switch v.(type) {
-		case int64, float64:
+		case int64, float64, float32, int32, int16, int8, uint8, uint16, uint32, uint64:
+			return true
+		}
+
+		return false
+	case "usint":
+		switch v.(type) {
+		case int64, int32, int16, int8, uint8, uint16, uint32, uint64:
 			return true
 		}
 
 		return false
 	case "int":
-		_, ok := v.(int64)
+		switch v.(type) {
+		case int64, int32, int16, int8, uint8, uint16, uint32, uint64, rawint64:
+			return true
+		}
 
-		return ok
+		return false
+	case "uint":
+		switch v.(type) {
+		case uint8, uint16, uint32, uint, uint64:
+			return true
+		}
+
+		return false
 	case "float":
 		_, ok := v.(float64)
+		if ok {
+			return ok
+		}
+
+		_, ok = v.(float32)
+		if ok {
+			return ok
+		}
 
 		return ok
 	case "bool":
@@ -177,6 +248,38 @@ func checkDataType(expected string, v any) bool {
 	case "func":
 		_, ok := v.(*FuncDec)
 
+		return ok
+	case "bigint":
+		_, ok := v.(*big.Int)
+
+		return ok
+	case "decimal":
+		_, ok := v.(*Decimal)
+
+		return ok
+	case "task":
+		_, ok := v.(*Task)
+
+		return ok
+	case "chan":
+		_, ok := v.(*Channel)
+
+		return ok
+	case "mutex":
+		_, ok := v.(*sync.Mutex)
+
+		return ok
+	case "waitgroup":
+		_, ok := v.(*sync.WaitGroup)
+
+		return ok
+	case "random":
+		_, ok := v.(*RandomGenerator)
+
+		return ok
+	case "module":
+		_, ok := v.(*Module)
+
 		return ok
 	}
 	return false
@@ -218,11 +321,13 @@ func throw(filename string, errForm string, x, y int, v ...any) {
 		errMsg = strings.ReplaceAll(errMsg, "non", "non-")
 	}
 
+	flushStdout()
 	fmt.Println(errMsg)
 	os.Exit(1)
 }
 
 func throwNoPos(errForm string, v ...any) {
+	flushStdout()
 	fmt.Printf(shortennedPLName+": "+errForm+"\n", v...)
 	os.Exit(1)
 }
@@ -238,7 +343,7 @@ func getAbsPath(relPath string) string {
 	return abs
 }
 
-func run(fileAbs, fileRel string, info bool) map[any]*Cell {
+func run(fileAbs, fileRel string, info bool) *Module {
 	if !strings.HasSuffix(fileAbs, fileType) {
 		fileAbs += fileType
 	}
@@ -248,7 +353,9 @@ func run(fileAbs, fileRel string, info bool) map[any]*Cell {
 		throwNoPos(err.Error())
 	}
 
+	filesMutex.Lock()
 	filesBeingUsed = append(filesBeingUsed, [2]string{fileAbs, fileRel})
+	filesMutex.Unlock()
 
 	lexer := NewLexer(fileRel, content)
 	tokens := lexer.GetTokens()
@@ -265,17 +372,26 @@ func run(fileAbs, fileRel string, info bool) map[any]*Cell {
 // ? go build -o bin/yks.exe yks
 // *go run -race yks runinfo test.yks
 func main() { //*go run yks runinfo test.yks
+	defer flushStdout()
+
 	commands["build"] = func(args []string) {
 		//Lox
 		fmt.Println("Kys")
 	}
 	commands["run"] = func(args []string) {
+		args = cutRunFlags(args)
+		if entry, ok := manifestEntry(); ok && len(args) == 0 {
+			args = []string{entry}
+		}
 		path := args[0]
+		scriptArgs = args[1:]
 
 		run(getAbsPath(path), path, false)
 	}
 	commands["runinfo"] = func(args []string) {
+		args = cutRunFlags(args)
 		path := args[0]
+		scriptArgs = args[1:]
 
 		run(getAbsPath(path), path, true)
 	}
@@ -295,6 +411,8 @@ func main() { //*go run yks runinfo test.yks
 	commands["arch"] = func(args []string) {
 		fmt.Println(runtime.GOARCH)
 	}
+	commands["which"] = which
+	commands["mod"] = mod
 	commands["help"] = help
 
 	if len(args) <= 0 {
@@ -305,6 +423,10 @@ func main() { //*go run yks runinfo test.yks
 	cmd := args[0]
 
 	cmdFunc, ok := commands[cmd]
+	if !ok && strings.HasSuffix(cmd, fileType) { //Script started through a shebang line
+		cmdFunc, ok = commands["run"], true
+		args = append([]string{"run"}, args...)
+	}
 	if !ok {
 		help([]string{})
 		return
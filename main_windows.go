@@ -4,10 +4,12 @@ import (
 	"C"
 	"fmt"
 	"math"
+	"math/big"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 )
 import (
 	"log"
@@ -37,9 +39,9 @@ func argsCheck(v []any, min, max int, expectedDataTypes ...string) {
 	inter := v[2].(*Interpreter)
 
 	if len(v) < min+BUILTIN_SPECIALS {
-		throw(inter.CurrentFileName, "Attempt to pass less arguments to a function call than function actually need, minimum is %d.", x, y, min)
+		inter.throw("Attempt to pass less arguments to a function call than function actually need, minimum is %d.", x, y, min)
 	} else if len(v) > max+3 {
-		throw(inter.CurrentFileName, "Attempt to pass more arguments to a function call than function actually need, maximum is %d.", x, y, max)
+		inter.throw("Attempt to pass more arguments to a function call than function actually need, maximum is %d.", x, y, max)
 	} else {
 		args := v[BUILTIN_SPECIALS:]
 
@@ -49,7 +51,7 @@ func argsCheck(v []any, min, max int, expectedDataTypes ...string) {
 			argument := args[i]
 
 			if !checkDataType(expectedDataType, argument) {
-				throw(inter.CurrentFileName, "Invalid argument #%d. Expected %s.", x, y, i+1, expectedDataType)
+				inter.throw("Invalid argument #%d. Expected %s.", x, y, i+1, expectedDataType)
 			}
 		}
 	}
@@ -88,6 +90,12 @@ func mustNTOF64(n any) float64 {
 		return float64(toInt64(n))
 	case uint8, uint16, uint, uint32, uint64:
 		return float64(toUint64(n))
+	case *big.Int:
+		f, _ := new(big.Float).SetInt(n).Float64()
+		return f
+	case *Decimal:
+		f, _ := strconv.ParseFloat(n.String(), 64)
+		return f
 	}
 	return 0
 }
@@ -102,7 +110,7 @@ func getValueType(v any) string {
 		return "f32"
 	case float64:
 		return "f64"
-	case int64:
+	case int64, rawint64:
 		return "i64"
 	case int32:
 		return "i32"
@@ -110,7 +118,7 @@ func getValueType(v any) string {
 		return "i16"
 	case int8:
 		return "i8"
-	case uint64:
+	case uint64, rawuint64:
 		return "u64"
 	case uint32:
 		return "u32"
@@ -134,6 +142,22 @@ func getValueType(v any) string {
 		return "unsafe.pointer"
 	case error:
 		return "error"
+	case *big.Int:
+		return "bigint"
+	case *Decimal:
+		return "decimal"
+	case *Task:
+		return "task"
+	case *Channel:
+		return "chan"
+	case *sync.Mutex:
+		return "mutex"
+	case *sync.WaitGroup:
+		return "waitgroup"
+	case *RandomGenerator:
+		return "random"
+	case *Module:
+		return "module"
 	}
 	return "unknown"
 }
@@ -219,6 +243,38 @@ func checkDataType(expected string, v any) bool {
 	case "func":
 		_, ok := v.(*FuncDec)
 
+		return ok
+	case "bigint":
+		_, ok := v.(*big.Int)
+
+		return ok
+	case "decimal":
+		_, ok := v.(*Decimal)
+
+		return ok
+	case "task":
+		_, ok := v.(*Task)
+
+		return ok
+	case "chan":
+		_, ok := v.(*Channel)
+
+		return ok
+	case "mutex":
+		_, ok := v.(*sync.Mutex)
+
+		return ok
+	case "waitgroup":
+		_, ok := v.(*sync.WaitGroup)
+
+		return ok
+	case "random":
+		_, ok := v.(*RandomGenerator)
+
+		return ok
+	case "module":
+		_, ok := v.(*Module)
+
 		return ok
 	}
 	return false
@@ -266,11 +322,13 @@ func throw(filename, errForm string, x, y int, v ...any) {
 		errMsg = strings.ReplaceAll(errMsg, "non", "non-")
 	}
 
+	flushStdout()
 	fmt.Println(errMsg)
 	os.Exit(1)
 }
 
 func throwNoPos(errForm string, v ...any) {
+	flushStdout()
 	fmt.Printf(shortennedPLName+": "+errForm+"\n", v...)
 	os.Exit(1)
 }
@@ -321,7 +379,7 @@ func loadLibraryIntoScope(interpreter_filename string, importPath string, node *
 
 			suc := scope.Add(proc, "DLL_PROC", "string", x, y)
 			if !suc {
-				throw(inter.CurrentFileName, "unsuccessfull", x, y)
+				inter.throw("unsuccessfull", x, y)
 			}
 
 			return []any{proc.Addr()}
@@ -329,7 +387,7 @@ func loadLibraryIntoScope(interpreter_filename string, importPath string, node *
 	}, node.X, node.Y)
 }
 
-func run(fileAbs, fileRel string, info bool) map[any]*Cell {
+func run(fileAbs, fileRel string, info bool) *Module {
 	if !strings.HasSuffix(fileAbs, fileType) {
 		fileAbs += fileType
 	}
@@ -339,7 +397,9 @@ func run(fileAbs, fileRel string, info bool) map[any]*Cell {
 		throwNoPos(err.Error())
 	}
 
+	filesMutex.Lock()
 	filesBeingUsed = append(filesBeingUsed, [2]string{fileAbs, fileRel})
+	filesMutex.Unlock()
 
 	lexer := NewLexer(fileRel, content)
 	tokens := lexer.GetTokens()
@@ -376,27 +436,36 @@ func outputTokens(tokens []Token) {
 // ? go build -ldflags="-s -w" -o bin/yks.exe yks
 // *go run -race yks runinfo test.yks
 func main() { //*go run yks run test.yks
+	defer flushStdout()
+
 	commands["build"] = func(args []string) {
 		fmt.Println("Jly")
 	}
 	
 	commands["run"] = func(args []string) {
+		args = cutRunFlags(args)
+		if entry, ok := manifestEntry(); ok && len(args) == 0 {
+			args = []string{entry}
+		}
 		if len(args) == 0 {
 			help([]string{})
 			return
 		}
 
 		path := args[0]
+		scriptArgs = args[1:]
 
 		run(getAbsPath(path), path, false)
 	}
 	commands["runinfo"] = func(args []string) {
+		args = cutRunFlags(args)
 		if len(args) == 0 {
 			help([]string{})
 			return
 		}
 
 		path := args[0]
+		scriptArgs = args[1:]
 
 		run(getAbsPath(path), path, true)
 	}
@@ -421,6 +490,8 @@ func main() { //*go run yks run test.yks
 	commands["arch"] = func(args []string) {
 		fmt.Println(runtime.GOARCH)
 	}
+	commands["which"] = which
+	commands["mod"] = mod
 	commands["help"] = help
 
 	if len(args) <= 0 {
@@ -431,6 +502,10 @@ func main() { //*go run yks run test.yks
 	cmd := args[0]
 
 	cmdFunc, ok := commands[cmd]
+	if !ok && strings.HasSuffix(cmd, fileType) { //Script started through a shebang line
+		cmdFunc, ok = commands["run"], true
+		args = append([]string{"run"}, args...)
+	}
 	if !ok {
 		help([]string{})
 		return
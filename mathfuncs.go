@@ -0,0 +1,357 @@
+package main
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"sync"
+)
+
+// Generator of the random sub-API, calls are serialized because rand.Rand isn't safe for concurrent use
+type RandomGenerator struct {
+	mutex sync.Mutex
+	Rand  *rand.Rand
+}
+
+// Source that reads the operating system's cryptographically secure generator
+type cryptoSource struct{}
+
+func (cryptoSource) Uint64() uint64 {
+	var b [8]byte
+	crand.Read(b[:])
+
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+var (
+	mathConstants = map[string]any{
+		"pi":    math.Pi,
+		"e":     math.E,
+		"phi":   math.Phi,
+		"sqrt2": math.Sqrt2,
+		"ln2":   math.Ln2,
+		"ln10":  math.Ln10,
+		"inf":   math.Inf(1),
+		"nan":   math.NaN(),
+
+		"minI8":  int8(math.MinInt8),
+		"maxI8":  int8(math.MaxInt8),
+		"minI16": int16(math.MinInt16),
+		"maxI16": int16(math.MaxInt16),
+		"minI32": int32(math.MinInt32),
+		"maxI32": int32(math.MaxInt32),
+		"minI64": int64(math.MinInt64),
+		"maxI64": int64(math.MaxInt64),
+		"maxU8":  uint8(math.MaxUint8),
+		"maxU16": uint16(math.MaxUint16),
+		"maxU32": uint32(math.MaxUint32),
+		"maxU64": uint64(math.MaxUint64),
+
+		"maxF32":      float32(math.MaxFloat32),
+		"smallestF32": float32(math.SmallestNonzeroFloat32),
+		"maxF64":      math.MaxFloat64,
+		"smallestF64": math.SmallestNonzeroFloat64,
+	}
+
+	//Float functions of Go's math package, f32 arguments give f32 results and others f64
+	unaryMathFuncs = map[string]func(float64) float64{
+		"sqrt":  math.Sqrt,
+		"cbrt":  math.Cbrt,
+		"floor": math.Floor,
+		"ceil":  math.Ceil,
+		"round": math.Round,
+		"trunc": math.Trunc,
+		"sin":   math.Sin,
+		"cos":   math.Cos,
+		"tan":   math.Tan,
+		"asin":  math.Asin,
+		"acos":  math.Acos,
+		"atan":  math.Atan,
+		"sinh":  math.Sinh,
+		"cosh":  math.Cosh,
+		"tanh":  math.Tanh,
+		"exp":   math.Exp,
+		"exp2":  math.Exp2,
+		"expm1": math.Expm1,
+		"log":   math.Log,
+		"log2":  math.Log2,
+		"log10": math.Log10,
+		"log1p": math.Log1p,
+	}
+	binaryMathFuncs = map[string]func(float64, float64) float64{
+		"pow":       math.Pow,
+		"atan2":     math.Atan2,
+		"hypot":     math.Hypot,
+		"fmod":      math.Mod,
+		"remainder": math.Remainder,
+		"copysign":  math.Copysign,
+	}
+)
+
+var (
+	mathFuncs = map[string]func(v ...any) []any{
+		"math_const": func(v ...any) []any {
+			argsCheck(v, 1, 1, "string")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			value, ok := mathConstants[v[0].(string)]
+			if !ok {
+				inter.throw("Unknown math constant '%s'.", x, y, v[0])
+			}
+
+			return []any{value}
+		},
+
+		"math_isnan": func(v ...any) []any {
+			argsCheck(v, 1, 1, "number")
+
+			return []any{math.IsNaN(mustNTOF64(v[BUILTIN_SPECIALS]))}
+		},
+
+		"math_isinf": func(v ...any) []any {
+			argsCheck(v, 1, 1, "number")
+
+			return []any{math.IsInf(mustNTOF64(v[BUILTIN_SPECIALS]), 0)}
+		},
+
+		"math_abs": func(v ...any) []any {
+			argsCheck(v, 1, 1, "number")
+
+			value := v[BUILTIN_SPECIALS]
+
+			switch {
+			case checkDataType("uint", value):
+				return []any{value}
+			case checkDataType("int", value):
+				//Minimal value of the type wraps around like negation does
+				n := toInt64(value)
+				if n < 0 {
+					n = -n
+				}
+
+				return []any{toInt(n, -twoDigitStr(getValueType(value)[1:]))}
+			}
+
+			return []any{floatResult(value, math.Abs(mustNTOF64(value)))}
+		},
+
+		"math_min": func(v ...any) []any {
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			return []any{inter.extremum(v[BUILTIN_SPECIALS:], -1, x, y)}
+		},
+
+		"math_max": func(v ...any) []any {
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			return []any{inter.extremum(v[BUILTIN_SPECIALS:], 1, x, y)}
+		},
+
+		"math_clamp": func(v ...any) []any {
+			argsCheck(v, 3, 3, "number", "number", "number")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			value, low := inter.numberOperands(v[0], v[1], x, y)
+			value, high := inter.numberOperands(value, v[2], x, y)
+
+			if cmp, _ := compareValues(low, high); cmp > 0 {
+				inter.throw("Lower bound of clamp is greater than the upper one.", x, y)
+			}
+
+			if cmp, _ := compareValues(value, low); cmp < 0 {
+				return []any{low}
+			}
+			if cmp, _ := compareValues(value, high); cmp > 0 {
+				return []any{high}
+			}
+
+			return []any{value}
+		},
+
+		"random_new": func(v ...any) []any {
+			argsCheck(v, 0, 1, "int")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			if len(v) == 0 {
+				return []any{&RandomGenerator{Rand: rand.New(rand.NewPCG(cryptoSource{}.Uint64(), cryptoSource{}.Uint64()))}}
+			}
+
+			//Same seed gives the same sequence on every run and platform
+			seed := uint64(toInt64(v[0]))
+
+			return []any{&RandomGenerator{Rand: rand.New(rand.NewPCG(seed, seed))}}
+		},
+
+		"random_crypto": func(v ...any) []any {
+			argsCheck(v, 0, 0)
+
+			return []any{&RandomGenerator{Rand: rand.New(cryptoSource{})}}
+		},
+
+		"random_range": func(v ...any) []any {
+			argsCheck(v, 3, 3, "random", "int", "int")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			low, high := toInt64(v[1]), toInt64(v[2])
+			if low >= high {
+				inter.throw("Range of random numbers is empty.", x, y)
+			}
+
+			var n int64
+			inter.withRandom(v[0], x, y, func(r *rand.Rand) {
+				n = low + int64(r.Uint64N(uint64(high-low)))
+			})
+
+			return []any{n}
+		},
+
+		"random_float": func(v ...any) []any {
+			argsCheck(v, 1, 1, "random")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			var f float64
+			inter.withRandom(v[0], x, y, func(r *rand.Rand) {
+				f = r.Float64()
+			})
+
+			return []any{f}
+		},
+
+		"random_choice": func(v ...any) []any {
+			argsCheck(v, 2, 2, "random", "table")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			cells := tableCells(v[1].(*Map))
+			if len(cells) == 0 {
+				inter.throw("Attempt to choose from an empty table.", x, y)
+			}
+
+			var i int
+			inter.withRandom(v[0], x, y, func(r *rand.Rand) {
+				i = r.IntN(len(cells))
+			})
+
+			return []any{cells[i].Get()}
+		},
+
+		"random_shuffle": func(v ...any) []any {
+			argsCheck(v, 2, 2, "random", "table")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			table := v[1].(*Map)
+			cells := tableCells(table)
+
+			inter.withRandom(v[0], x, y, func(r *rand.Rand) {
+				r.Shuffle(len(cells), func(i, j int) {
+					cells[i], cells[j] = cells[j], cells[i]
+				})
+			})
+			table.SetSequence(cells)
+
+			return nil
+		},
+	}
+)
+
+func init() {
+	for name, f := range unaryMathFuncs {
+		mathFuncs["math_"+name] = func(v ...any) []any {
+			argsCheck(v, 1, 1, "number")
+
+			value := v[BUILTIN_SPECIALS]
+
+			return []any{floatResult(value, f(mustNTOF64(value)))}
+		}
+	}
+	for name, f := range binaryMathFuncs {
+		mathFuncs["math_"+name] = func(v ...any) []any {
+			argsCheck(v, 2, 2, "number", "number")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			return []any{floatResult(v[0], f(mustNTOF64(v[0]), mustNTOF64(v[1])))}
+		}
+	}
+
+	maps.Copy(builtinFuncs, mathFuncs)
+}
+
+// f32 arguments keep their type, other numbers give f64
+func floatResult(arg any, f float64) any {
+	if checkType[float32](arg) {
+		return float32(f)
+	}
+
+	return f
+}
+
+// Smallest value for the sign -1 and the largest for 1, all values must have the same type
+func (inter *Interpreter) extremum(values []any, sign, x, y int) any {
+	//Single table gives the extremum of its elements like the table builtins, void for an empty one
+	if len(values) == 1 {
+		if table, ok := values[0].(*Map); ok {
+			cells := tableCells(table)
+			if len(cells) == 0 {
+				return nil
+			}
+
+			values = make([]any, len(cells))
+			for i, cell := range cells {
+				values[i] = cell.Get()
+			}
+		}
+	}
+
+	if len(values) == 0 {
+		inter.throw("Function requires one or more arguments.", x, y)
+	}
+
+	result := values[0]
+	for i, value := range values {
+		if !checkDataType("number", value) {
+			inter.throw("Invalid argument #%d. Expected %s.", x, y, i+1, "number")
+		}
+
+		result, value = inter.numberOperands(result, value, x, y)
+		if cmp, _ := compareValues(value, result); cmp == sign {
+			result = value
+		}
+	}
+
+	return result
+}
+
+func (inter *Interpreter) withRandom(value any, x, y int, f func(r *rand.Rand)) {
+	generator, _ := value.(*RandomGenerator)
+	if generator == nil {
+		inter.throw("Attempt to use a non-existing random generator.", x, y)
+	}
+
+	generator.mutex.Lock()
+	defer generator.mutex.Unlock()
+
+	f(generator.Rand)
+}
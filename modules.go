@@ -0,0 +1,211 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Completed file, only names declared in the file itself without leading underscore are exported
+type Module struct {
+	Name    string
+	Scope   *Scope
+	Exports map[string]*Cell
+}
+
+func NewModule(inter *Interpreter, scope *Scope) *Module {
+	module := &Module{
+		Name:    inter.CurrentFileName,
+		Scope:   scope,
+		Exports: make(map[string]*Cell),
+	}
+
+	for _, node := range inter.AST {
+		for _, name := range declaredNames(node) {
+			cell, ok := scope.Data[name]
+			if !ok {
+				continue
+			}
+
+			switch value := cell.Get().(type) {
+			case *FuncDec:
+				bindToModule(value, scope)
+			case *Structure:
+				for _, field := range value.Fields {
+					if field.Func != nil {
+						bindToModule(field.Func, scope)
+					}
+				}
+			case *StructObject:
+				if value == nil {
+					break
+				}
+
+				//Instances made while completing the module copied the methods before they were bound
+				for _, method := range value.Methods {
+					if funcDec, ok := method.Func.Get().(*FuncDec); ok {
+						bindToModule(funcDec, scope)
+					}
+				}
+			}
+
+			if !strings.HasPrefix(name, "_") {
+				module.Exports[name] = cell
+			}
+		}
+	}
+
+	return module
+}
+
+// Functions of the module are completed in the module scope, so they can use its private names and imports
+func bindToModule(funcDec *FuncDec, scope *Scope) {
+	if funcDec.Template == nil && funcDec.Module == nil {
+		funcDec.Module = scope
+	}
+}
+
+func declaredNames(node Node) []string {
+	switch node := node.(type) {
+	case *FuncDec:
+		return []string{node.Identifier.Value}
+	case *StructDeclNode:
+		return []string{node.Identifier.Value}
+	case *VarDec:
+		names := make([]string, len(node.Identifier))
+		for i, ident := range node.Identifier {
+			names[i] = ident.Value
+		}
+
+		return names
+	case *DestructDec:
+		names := make([]string, len(node.Pattern.Targets))
+		for i, target := range node.Pattern.Targets {
+			names[i] = target.Identifier.Value
+		}
+
+		return names
+	}
+
+	return nil
+}
+
+// Adds the module itself, selected names or all exported names of the module into the scope
+func (inter *Interpreter) AddModule(node *Import, module *Module, scope *Scope) {
+	add := func(name string, value any, dataType string, x, y int) {
+		//Imported names shadow builtins the same way declarations do
+		if cell, ok := scope.Data[name]; ok && !isBuiltin(cell) {
+			inter.throw("Attempt to redeclare a variable '%s'.", x, y, name)
+		}
+
+		//Instance of a structure that wasn't imported along with it can't be typed by its name
+		if structure := module.Scope.GetCell(dataType); structure != nil && structure.DataType == "struct" && scope.GetCell(dataType) == nil {
+			dataType = "any"
+		}
+
+		scope.Add(name, value, dataType, x, y)
+	}
+
+	switch {
+	case node.Alias.Value != "":
+		add(node.Alias.Value, module, "module", node.X, node.Y)
+	case len(node.Names) > 0:
+		for _, name := range node.Names {
+			cell, ok := module.Exports[name.Value]
+			if !ok {
+				inter.throw("Module '%s' doesn't export '%s'.", name.X, name.Y, module.Name, name.Value)
+			}
+
+			add(name.Value, cell.Load(), cell.DataType, name.X, name.Y)
+		}
+	default:
+		//Structures are added first, so instances of them keep their types
+		for _, structures := range []bool{true, false} {
+			for name, cell := range module.Exports {
+				if (cell.DataType == "struct") == structures {
+					add(name, cell.Load(), cell.DataType, node.X, node.Y)
+				}
+			}
+		}
+	}
+}
+
+// Directories where imports are searched: directory of the importing file, vendored dependencies of the project, --lib-path flags, YKS_PATH and the standard library
+func searchPaths(importerDir string) []string {
+	paths := []string{importerDir}
+
+	//Vendored dependencies import each other through the vendor directory of the project
+	for dir := importerDir; ; {
+		manifest, ok := findManifest(dir)
+		if !ok {
+			break
+		}
+		paths = append(paths, filepath.Join(manifest.Dir, vendorDir))
+
+		dir = filepath.Dir(manifest.Dir)
+		if dir == manifest.Dir {
+			break
+		}
+	}
+	paths = append(paths, libPaths...)
+	paths = append(paths, filepath.SplitList(os.Getenv(libPathEnvVar))...)
+
+	return append(paths, libs)
+}
+
+// Finds the file of the module, OS specific files are preferred inside of each directory
+func resolveModule(pathNS, importerDir string) (string, bool) {
+	dirs := searchPaths(importerDir)
+	if filepath.IsAbs(pathNS) {
+		dirs = []string{""}
+	}
+
+	for _, dir := range dirs {
+		//Dependency directory is imported through the entry of its manifest
+		if manifest, err := ReadManifest(filepath.Join(dir, pathNS)); err == nil && manifest.Entry != "" {
+			entry := filepath.Join(manifest.Dir, manifest.Entry)
+			if _, err := os.Stat(entry); err == nil {
+				return getAbsPath(entry), true
+			}
+		}
+
+		for _, tag := range osTags {
+			finalPath := filepath.Join(dir, pathNS) + tag + fileType
+
+			info, err := os.Stat(finalPath)
+			if err == nil && !info.IsDir() {
+				return getAbsPath(finalPath), true
+			}
+		}
+	}
+
+	return "", false
+}
+
+func isBuiltin(cell *Cell) bool {
+	funcDec, ok := cell.Load().(*FuncDec)
+
+	return ok && funcDec.Template != nil
+}
+
+// yks which module: shows the file that the import resolves to from the working directory
+func which(args []string) {
+	args = cutRunFlags(args)
+	if len(args) == 0 {
+		help([]string{})
+		return
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		throwNoPos(err.Error())
+	}
+
+	pathNS, _ := strings.CutSuffix(args[0], fileType)
+	path, ok := resolveModule(pathNS, wd)
+	if !ok {
+		throwNoPos("Invalid file or library '%s'", args[0])
+	}
+
+	println(path)
+}
@@ -56,6 +56,38 @@ func (varDec *VarDec) Line() int {
 	return varDec.Y
 }
 
+type DestructTarget struct {
+	Key                         []Node //Key of the element in table pattern
+	Field, Identifier, DataType IdentNode
+	Optional, Rest              bool
+}
+
+type DestructPattern struct {
+	Struct  bool
+	Targets []*DestructTarget
+	X, Y    int
+}
+
+func (pattern *DestructPattern) Position() int {
+	return pattern.X
+}
+func (pattern *DestructPattern) Line() int {
+	return pattern.Y
+}
+
+type DestructDec struct {
+	Pattern *DestructPattern
+	Value   []Node
+	X, Y    int
+}
+
+func (destructDec *DestructDec) Position() int {
+	return destructDec.X
+}
+func (destructDec *DestructDec) Line() int {
+	return destructDec.Y
+}
+
 type NilNode struct {
 	X, Y int
 }
@@ -109,6 +141,9 @@ type FuncDec struct {
 	Arguments, ArgumentsDataTypes, ReturnDataTypes []IdentNode
 	Body                                           []Node
 	Template                                       func(v ...any) []any
+	Checked                                        bool   //Integer overflows are errors inside of the function
+	Module                                         *Scope //Main scope of the module where the function is declared
+	Scope                                          *Scope //Main scope of the file where the top-level function is declared
 	X, Y                                           int
 }
 
@@ -182,6 +217,18 @@ func (floatNode *FloatNode) Line() int {
 	return floatNode.Y
 }
 
+type BigNumNode struct {
+	Value any //*big.Int or *Decimal
+	X, Y  int
+}
+
+func (bigNumNode *BigNumNode) Position() int {
+	return bigNumNode.X
+}
+func (bigNumNode *BigNumNode) Line() int {
+	return bigNumNode.Y
+}
+
 type StrNode struct {
 	Value string
 	X, Y  int
@@ -238,6 +285,19 @@ type GetElementNode struct {
 	X, Y int
 }
 
+type SliceNode struct {
+	Start, End []Node
+
+	X, Y int
+}
+
+func (sliceNode *SliceNode) Position() int {
+	return sliceNode.X
+}
+func (sliceNode *SliceNode) Line() int {
+	return sliceNode.Y
+}
+
 type SetElem struct {
 	Elem  *GetElementNode
 	Value []Node
@@ -312,6 +372,7 @@ func (wlNode *WhileNode) Line() int {
 
 type ForeachNode struct {
 	KeyIdent, ValueIdent IdentNode
+	ValuePattern         *DestructPattern
 	CycleValue           []Node
 	Body                 []Node
 	X, Y                 int
@@ -324,6 +385,61 @@ func (foreachNode *ForeachNode) Line() int {
 	return foreachNode.Y
 }
 
+type SpawnNode struct {
+	Call []Node
+	X, Y int
+}
+
+func (spawnNode *SpawnNode) Position() int {
+	return spawnNode.X
+}
+func (spawnNode *SpawnNode) Line() int {
+	return spawnNode.Y
+}
+
+type DeferNode struct {
+	Call []Node
+	X, Y int
+}
+
+func (deferNode *DeferNode) Position() int {
+	return deferNode.X
+}
+func (deferNode *DeferNode) Line() int {
+	return deferNode.Y
+}
+
+type SelectCase struct {
+	Idents []IdentNode //Receive value and ok flag
+	Call   *FuncCall   //recv, send or timeout
+	Body   []Node
+	X, Y   int
+}
+
+type SelectStmt struct {
+	Cases []*SelectCase
+	X, Y  int
+}
+
+func (selectStmt *SelectStmt) Position() int {
+	return selectStmt.X
+}
+func (selectStmt *SelectStmt) Line() int {
+	return selectStmt.Y
+}
+
+type WithNode struct {
+	Mutex, Body []Node
+	X, Y        int
+}
+
+func (withNode *WithNode) Position() int {
+	return withNode.X
+}
+func (withNode *WithNode) Line() int {
+	return withNode.Y
+}
+
 type BreakNode struct {
 	X, Y int
 }
@@ -359,8 +475,10 @@ func (rtNode *ReturnNode) Line() int {
 }
 
 type Import struct {
-	Path []Node
-	X, Y int
+	Path  []Node
+	Alias IdentNode   //import "path" as alias
+	Names []IdentNode //import { names } from "path"
+	X, Y  int
 }
 
 func (importNode *Import) Position() int {
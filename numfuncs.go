@@ -0,0 +1,102 @@
+package main
+
+import (
+	"maps"
+	"math"
+)
+
+var (
+	numberFuncs = map[string]func(v ...any) []any{
+		"wrapping_add": func(v ...any) []any {
+			argsCheck(v, 2, 2, "number", "number")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			a, b := inter.numberOperands(v[0], v[1], x, y)
+			bits := twoDigitStr(getValueType(a)[1:])
+
+			switch {
+			case checkDataType("uint", a):
+				return []any{toUint(toUint64(a)+toUint64(b), bits)}
+			case checkDataType("int", a):
+				return []any{toInt(toInt64(a)+toInt64(b), -bits)}
+			case bits == 32:
+				return []any{float32(mustNTOF64(a) + mustNTOF64(b))}
+			}
+
+			return []any{mustNTOF64(a) + mustNTOF64(b)}
+		},
+
+		"saturating_add": func(v ...any) []any {
+			argsCheck(v, 2, 2, "number", "number")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			a, b := inter.numberOperands(v[0], v[1], x, y)
+			bits := twoDigitStr(getValueType(a)[1:])
+
+			switch {
+			case checkDataType("uint", a):
+				if uintOverflows("add", toUint64(a), toUint64(b), bits) {
+					return []any{toUint(math.MaxUint64>>(64-bits), bits)}
+				}
+
+				return []any{toUint(toUint64(a)+toUint64(b), bits)}
+			case checkDataType("int", a):
+				if intOverflows("add", toInt64(a), toInt64(b), bits) {
+					if toInt64(b) < 0 {
+						return []any{toInt(math.MinInt64>>(64-bits), -bits)}
+					}
+					return []any{toInt(math.MaxInt64>>(64-bits), -bits)}
+				}
+
+				return []any{toInt(toInt64(a)+toInt64(b), -bits)}
+			case bits == 32:
+				return []any{float32(mustNTOF64(a) + mustNTOF64(b))}
+			}
+
+			return []any{mustNTOF64(a) + mustNTOF64(b)}
+		},
+
+		"decimal": func(v ...any) []any {
+			argsCheck(v, 2, 2, "any", "int")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			value, ok := toBigNumber(v[0], "decimal")
+			if !ok {
+				inter.throw("Unable to convert '%s' value to decimal.", x, y, getValueType(v[0]))
+			}
+
+			scale := toInt64(v[1])
+			if scale < 0 {
+				inter.throw("Decimal scale cannot be negative.", x, y)
+			}
+
+			return []any{value.(*Decimal).Rescale(int(scale))}
+		},
+	}
+)
+
+func init() {
+	maps.Copy(builtinFuncs, numberFuncs)
+}
+
+// Brings arguments of number builtins to the same type like binary operations do
+func (inter *Interpreter) numberOperands(a, b any, x, y int) (any, any) {
+	if !strictMode {
+		a, b = promoteOperands(a, b)
+	}
+
+	if aType, bType := getValueType(a), getValueType(b); aType != bType {
+		inter.throw("Unable to perform operation on values with different data types: '%s' and '%s'.", x, y, aType, bType)
+	}
+
+	return a, b
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"maps"
+	"os"
+	"strings"
+)
+
+var (
+	osFuncs = map[string]func(v ...any) []any{
+		"args": func(v ...any) []any {
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			table := newMap("string", len(scriptArgs))
+			for i, arg := range scriptArgs {
+				table.Set(int64(i), CLPTR(inter.CurrentScope, table.DataType, arg, x, y))
+			}
+			table.ToMemory()
+
+			return []any{table}
+		},
+
+		"getenv": func(v ...any) []any {
+			argsCheck(v, 1, 2, "string", "string")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			value, ok := os.LookupEnv(v[0].(string))
+			if !ok && len(v) > 1 {
+				value = format(v[1])
+			}
+
+			return []any{value}
+		},
+
+		"setenv": func(v ...any) []any {
+			argsCheck(v, 2, 2, "string", "string")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			if err := os.Setenv(v[0].(string), v[1].(string)); err != nil {
+				inter.throw("Unable to set environment variable: %s.", x, y, err.Error())
+			}
+
+			return nil
+		},
+
+		"unsetenv": func(v ...any) []any {
+			argsCheck(v, 1, 1, "string")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			os.Unsetenv(v[0].(string))
+
+			return nil
+		},
+
+		"environ": func(v ...any) []any {
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			environ := os.Environ()
+
+			table := newMap("string", len(environ))
+			for _, variable := range environ {
+				name, value, _ := strings.Cut(variable, "=")
+				table.Set(name, CLPTR(inter.CurrentScope, table.DataType, value, x, y))
+			}
+			table.ToMemory()
+
+			return []any{table}
+		},
+
+		"exit": func(v ...any) []any {
+			argsCheck(v, 0, 1, "int")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			code := int64(0)
+			if len(v) > 0 {
+				if !checkDataType("int", v[0]) {
+					inter.throw("Invalid argument #%d. Expected %s.", x, y, 1, "int")
+				}
+				code = toInt64(v[0])
+			}
+
+			flushStdout()
+			os.Exit(int(code))
+			return nil
+		},
+	}
+)
+
+func init() {
+	maps.Copy(builtinFuncs, osFuncs)
+}
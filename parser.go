@@ -12,15 +12,15 @@ const (
 
 var (
 	tokenTypesExpects = map[string][]string{
-		"int,float,ident,string,bool,nil,openbracket,opensqbrac,newstruct": {"openbracket", "asserttype", "valbits", "opensqbrac", "add", "bitor", "sub", "div", "mul", "pow",
-			"equals", "notequals", "greater", "less", "greatereq", "lesseq", "and", "or", "indexstruct"},
-		"add,sub,div,mul,pow,equals,notequals,greater,less,greatereq,lesseq,bitor,and,or,return,getptr,valbits": {"sub", "int", "float", "ident", "string", "bool", "openbracket", "nil"},
+		"int,float,ident,string,bool,nil,openbracket,opensqbrac,newstruct,spawn": {"openbracket", "asserttype", "valbits", "opensqbrac", "add", "bitor", "sub", "div", "mul", "mod", "pow",
+			"equals", "notequals", "is", "greater", "less", "greatereq", "lesseq", "and", "or", "indexstruct"},
+		"add,sub,div,mul,mod,pow,equals,notequals,is,greater,less,greatereq,lesseq,bitor,and,or,return,getptr,valbits": {"sub", "int", "float", "ident", "string", "bool", "openbracket", "nil"},
 		"opensqbrac,getptr": {"opensqbrac"},
 	}
 	binOpsList = []string{
 		"valbits",
-		"add", "sub", "div", "mul", "pow",
-		"equals", "notequals", "greater", "less", "greatereq", "lesseq",
+		"add", "sub", "div", "mul", "mod", "pow",
+		"equals", "notequals", "is", "greater", "less", "greatereq", "lesseq",
 		"bitor",
 		"and", "or",
 	}
@@ -177,6 +177,16 @@ func setLastElementOf[T any](s []T, v T) {
 func newDataTypeNode(token Token) Node {
 	x, y := token.Position, token.Line
 
+	switch token.Type {
+	case "int", "float":
+		if isBigNumber(token.Value) {
+			return &BigNumNode{
+				token.Value,
+				x, y,
+			}
+		}
+	}
+
 	switch token.Type {
 	case "int":
 		if checkType[rawuint64](token.Value) {
@@ -278,6 +288,12 @@ func (parser *Parser) Parse(nodes []Node, bodyParsing bool) []Node {
 
 		nodes = append(nodes, wlLoop)
 		return nodes
+	case "with":
+		withNode := parser.ParseWith()
+		withNode.X, withNode.Y = x, y
+
+		nodes = append(nodes, withNode)
+		return nodes
 	case "forloop":
 		foreachLoop := parser.ParseForeachLoop()
 		foreachLoop.X, foreachLoop.Y = x, y
@@ -285,6 +301,15 @@ func (parser *Parser) Parse(nodes []Node, bodyParsing bool) []Node {
 		nodes = append(nodes, foreachLoop)
 		return nodes
 	case "var":
+		switch parser.PeekNext().Type {
+		case "opensqbrac", "openbrace":
+			destructDec := parser.ParseDestructuring()
+			destructDec.X, destructDec.Y = x, y
+
+			nodes = append(nodes, destructDec)
+			return nodes
+		}
+
 		variable := parser.ParseVariable()
 		variable.X, variable.Y = x, y
 
@@ -294,6 +319,15 @@ func (parser *Parser) Parse(nodes []Node, bodyParsing bool) []Node {
 		function := parser.ParseFuncDecl()
 		function.X, function.Y = x, y
 
+		nodes = append(nodes, function)
+		return nodes
+	case "checked_attr":
+		parser.Next("func")
+
+		function := parser.ParseFuncDecl()
+		function.Checked = true
+		function.X, function.Y = x, y
+
 		nodes = append(nodes, function)
 		return nodes
 	case "break":
@@ -322,11 +356,7 @@ func (parser *Parser) Parse(nodes []Node, bodyParsing bool) []Node {
 
 		return nodes
 	case "import":
-		parser.Next()
-		nodes = append(nodes, &Import{
-			parser.ParseValue(),
-			x, y,
-		})
+		nodes = append(nodes, parser.ParseImport())
 
 		return nodes
 	case "indexstruct":
@@ -379,6 +409,30 @@ func (parser *Parser) Parse(nodes []Node, bodyParsing bool) []Node {
 	case "newstruct":
 		nodes = append(nodes, parser.ParseNewStruct())
 
+		return nodes
+	case "spawn":
+		parser.Next("ident")
+
+		nodes = appendDataType(&SpawnNode{
+			parser.ParseValue(),
+			x, y,
+		}, nodes)
+
+		return nodes
+	case "defer":
+		parser.Next("ident")
+
+		nodes = append(nodes, &DeferNode{
+			parser.ParseValue(),
+			x, y,
+		})
+
+		return nodes
+	case "select":
+		selectStmt := parser.ParseSelect()
+		selectStmt.X, selectStmt.Y = x, y
+
+		nodes = append(nodes, selectStmt)
 		return nodes
 	case "struct":
 		nodes = append(nodes, parser.ParseStructDecl())
@@ -483,8 +537,8 @@ func (parser *Parser) Parse(nodes []Node, bodyParsing bool) []Node {
 
 		return nodes
 	case "valbits",
-		"add", "sub", "div", "mul", "pow",
-		"equals", "notequals", "greater", "less", "greatereq", "lesseq",
+		"add", "sub", "div", "mul", "mod", "pow",
+		"equals", "notequals", "is", "greater", "less", "greatereq", "lesseq",
 		"bitor",
 		"and", "or":
 		lastNode := getLastNode(nodes)
@@ -532,7 +586,7 @@ func (parser *Parser) Parse(nodes []Node, bodyParsing bool) []Node {
 					binOpNode.L = getLastRightOperand(node)
 
 					setLastRightOperand(node, binOpNode)
-				case "mul", "div", "pow", "bitor":
+				case "mul", "div", "mod", "pow", "bitor":
 					binOpNode.L = node
 					nodes = replaceLastNodeWith(nodes, binOpNode)
 				case "add", "sub":
@@ -548,7 +602,7 @@ func (parser *Parser) Parse(nodes []Node, bodyParsing bool) []Node {
 					}
 				default:
 					switch currentToken.Type {
-					case "equals", "notequals", "greater", "less", "greatereq", "lesseq":
+					case "equals", "notequals", "is", "greater", "less", "greatereq", "lesseq":
 						binOpNode.L = node
 						nodes = replaceLastNodeWith(nodes, binOpNode)
 						parser.Next()
@@ -595,6 +649,13 @@ func (parser *Parser) Parse(nodes []Node, bodyParsing bool) []Node {
 			funcCall.Func = lastNode
 			funcCall.X, funcCall.Y = x, y
 
+			//Element type of the channel is written as a type name
+			if ident, ok := lastNode.(*IdentNode); ok && ident.Value == "chan" && len(funcCall.Arguments) > 0 {
+				if dataType, ok := funcCall.Arguments[0].(*IdentNode); ok {
+					funcCall.Arguments[0] = &StrNode{dataType.Value, dataType.X, dataType.Y}
+				}
+			}
+
 			return replaceLastNodeWith(nodes, funcCall)
 		}
 
@@ -799,7 +860,17 @@ FOREACHPAR:
 				parser.Next("assign")
 			}
 		case "comma":
-			parser.Next("ident")
+			parser.Next("ident", "opensqbrac", "openbrace")
+
+			token = parser.CurrentToken
+			if token.Type != "ident" {
+				foreachNode.ValuePattern = parser.ParseDestructPattern()
+
+				token = parser.CurrentToken
+				if token.Type != "assign" {
+					throw(parser.CurrentFileName, EXCEPTION_ERROR, token.Position, token.Line, "assign", token.Type)
+				}
+			}
 		case "openbrace":
 			foreachNode.Body = parser.ParseBody()
 			break FOREACHPAR
@@ -820,6 +891,29 @@ FOREACHPAR:
 	return foreachNode
 }
 
+func (parser *Parser) ParseWith() *WithNode {
+	withNode := &WithNode{}
+	mutex := []Node{}
+
+WITHPAR:
+	for parser.CurrentPosition >= 0 {
+		token := parser.CurrentToken
+
+		switch token.Type {
+		case "with":
+			parser.Next()
+		case "openbrace":
+			withNode.Body = parser.ParseBody()
+			break WITHPAR
+		default:
+			mutex = parser.Parse(mutex, false)
+		}
+	}
+	withNode.Mutex = mutex
+
+	return withNode
+}
+
 func (parser *Parser) ParseWhileLoop() *WhileNode {
 	wlNode := &WhileNode{}
 	condition := []Node{}
@@ -845,6 +939,7 @@ WHILEPAR:
 
 func (parser *Parser) ParseKey() []Node {
 	var key []Node
+	var slice *SliceNode
 
 	mainToken := parser.CurrentToken
 
@@ -858,13 +953,31 @@ KEYPAR:
 		case "closesqbrac":
 			parser.Next()
 			break KEYPAR
+		case tableKeyValueAssignTokenType:
+			if slice != nil {
+				throw(parser.CurrentFileName, "Slice expression cannot have more than one '%s'.", token.Position, token.Line, token.Value)
+			}
+
+			slice = &SliceNode{
+				Start: key,
+
+				X: token.Position, Y: token.Line,
+			}
+			key = nil
+
+			parser.Next()
 		default:
 			key = parser.Parse(key, false)
 		}
 	}
 
+	if slice != nil {
+		slice.End = key
+
+		return []Node{slice}
+	}
 	if len(key) == 0 {
-		throw(parser.CurrentFileName, "Key cannot be empty", 0, mainToken.Position, mainToken.Line)
+		throw(parser.CurrentFileName, "Key cannot be empty", mainToken.Position, mainToken.Line)
 	}
 	return key
 }
@@ -1097,15 +1210,21 @@ FIELDS:
 			fields = append(fields, fieldDeclNode)
 
 			parser.Next("comma")
-		case "func":
+		case "func", "checked_attr":
+			checked := token.Type == "checked_attr"
+			if checked {
+				parser.Next("func")
+			}
+
 			funcDecl := parser.ParseFuncDecl()
+			funcDecl.Checked = checked
 
 			fields = append(fields, &FieldDeclNode{
 				Identifier: funcDecl.Identifier,
 				Func:       funcDecl,
 			})
 		case "comma":
-			parser.Next("ident", "func", "closebrace")
+			parser.Next("ident", "func", "checked_attr", "closebrace")
 		case "closebrace":
 			parser.Next()
 			break FIELDS
@@ -1224,6 +1343,120 @@ VARPAR:
 	return varDec
 }
 
+func (parser *Parser) ParseDestructuring() *DestructDec {
+	parser.Next("opensqbrac", "openbrace")
+
+	destructDec := &DestructDec{
+		Pattern: parser.ParseDestructPattern(),
+	}
+
+	token := parser.CurrentToken
+	if token.Type != "assign" {
+		throw(parser.CurrentFileName, EXCEPTION_ERROR, token.Position, token.Line, "assign", token.Type)
+	}
+	parser.Next()
+
+	destructDec.Value = parser.ParseValue()
+
+	return destructDec
+}
+
+// [a, b, ...rest], ["key": a, "key2": b? i64] or {field, field: name}
+func (parser *Parser) ParseDestructPattern() *DestructPattern {
+	token := parser.CurrentToken
+
+	pattern := &DestructPattern{
+		Struct: token.Type == "openbrace",
+
+		X: token.Position, Y: token.Line,
+	}
+
+	closeTokenType := "closesqbrac"
+	if pattern.Struct {
+		closeTokenType = "closebrace"
+	}
+
+	parser.Next()
+
+PATTERNPAR:
+	for parser.CurrentPosition >= 0 {
+		token := parser.CurrentToken
+
+		switch token.Type {
+		case closeTokenType:
+			parser.Next()
+			break PATTERNPAR
+		case tableSeparatorTokenType:
+			parser.Unexpect(tableSeparatorTokenType)
+			parser.Next()
+		default:
+			if lastTarget := getLastElementOf(pattern.Targets); lastTarget != nil && lastTarget.Rest {
+				throw(parser.CurrentFileName, "Rest target must be the last one in the pattern.", token.Position, token.Line)
+			}
+
+			target := &DestructTarget{}
+
+			if pattern.Struct {
+				if token.Type != "ident" {
+					throw(parser.CurrentFileName, EXCEPTION_ERROR, token.Position, token.Line, "ident", token.Type)
+				}
+
+				target.Field = IdentNode{token.Value.(string), token.Position, token.Line}
+				if parser.PeekNext().Type == tableKeyValueAssignTokenType {
+					parser.NextTimes(2)
+				}
+			} else if token.Type != "ident" && token.Type != "rest" || parser.PeekNext().Type == tableKeyValueAssignTokenType {
+				target.Key = parser.ParseValue()
+
+				token = parser.CurrentToken
+				if token.Type != tableKeyValueAssignTokenType {
+					throw(parser.CurrentFileName, EXCEPTION_ERROR, token.Position, token.Line, tableKeyValueAssignTokenType, token.Type)
+				}
+				parser.Next()
+			}
+
+			parser.ParseDestructTarget(target, pattern.Struct)
+
+			pattern.Targets = append(pattern.Targets, target)
+		}
+	}
+
+	return pattern
+}
+
+func (parser *Parser) ParseDestructTarget(target *DestructTarget, structPattern bool) {
+	token := parser.CurrentToken
+
+	if token.Type == "rest" {
+		if structPattern || target.Key != nil {
+			throw(parser.CurrentFileName, INVALID_TOKEN_ERROR, token.Position, token.Line, token.Value)
+		}
+
+		target.Rest = true
+		parser.Next("ident")
+
+		token = parser.CurrentToken
+	}
+
+	if token.Type != "ident" {
+		throw(parser.CurrentFileName, EXCEPTION_ERROR, token.Position, token.Line, "ident", token.Type)
+	}
+	target.Identifier = IdentNode{token.Value.(string), token.Position, token.Line}
+	parser.Next()
+
+	token = parser.CurrentToken
+	if token.Type == "asserttype" && !target.Rest {
+		target.Optional = true
+		parser.Next()
+
+		token = parser.CurrentToken
+	}
+	if token.Type == "ident" {
+		target.DataType = IdentNode{token.Value.(string), token.Position, token.Line}
+		parser.Next()
+	}
+}
+
 // . 1: Arguments; 2: Arguments data types
 func (parser *Parser) ParseDeclArgs() ([]IdentNode, []IdentNode) {
 	args := []IdentNode{}
@@ -1427,6 +1660,101 @@ PAR_RETURNVAL:
 	return values
 }
 
+// import "path", import "path" as alias or import { names } from "path"
+func (parser *Parser) ParseImport() *Import {
+	importNode := &Import{
+		X: parser.CurrentToken.Position, Y: parser.CurrentToken.Line,
+	}
+
+	parser.Next()
+	if parser.CurrentToken.Type == "openbrace" {
+		for {
+			parser.Next("ident")
+			token := parser.CurrentToken
+			importNode.Names = append(importNode.Names, IdentNode{token.Value.(string), token.Position, token.Line})
+
+			parser.Next("comma", "closebrace")
+			if parser.CurrentToken.Type == "closebrace" {
+				break
+			}
+		}
+
+		parser.Next("ident")
+		if token := parser.CurrentToken; token.Value != "from" {
+			throw(parser.CurrentFileName, EXCEPTION_ERROR, token.Position, token.Line, "from", token.Value)
+		}
+		parser.Next()
+	}
+
+	importNode.Path = parser.ParseValue()
+
+	if token := parser.CurrentToken; token.Type == "ident" && token.Value == "as" && len(importNode.Names) == 0 {
+		parser.Next("ident")
+
+		token = parser.CurrentToken
+		importNode.Alias = IdentNode{token.Value.(string), token.Position, token.Line}
+		parser.Next()
+	}
+
+	return importNode
+}
+
+// select { case value, ok = recv(ch) {...} case send(ch, value) {...} case timeout(seconds) {...} }
+func (parser *Parser) ParseSelect() *SelectStmt {
+	selectStmt := &SelectStmt{}
+
+	parser.Next("openbrace")
+	parser.Next("case", "closebrace")
+
+SELECTPAR:
+	for parser.CurrentPosition >= 0 {
+		token := parser.CurrentToken
+
+		switch token.Type {
+		case "case":
+			selectCase := &SelectCase{
+				X: token.Position, Y: token.Line,
+			}
+			parser.Next("ident")
+
+			if next := parser.PeekNext().Type; next == "comma" || next == "assign" {
+				for {
+					identToken := parser.CurrentToken
+					selectCase.Idents = append(selectCase.Idents, IdentNode{identToken.Value.(string), identToken.Position, identToken.Line})
+
+					parser.Next("comma", "assign")
+					if parser.CurrentToken.Type == "assign" {
+						break
+					}
+					parser.Next("ident")
+				}
+				parser.Next("ident")
+			}
+
+			value := parser.ParseValue()
+			if len(value) != 1 || !checkType[*FuncCall](value[0]) {
+				throw(parser.CurrentFileName, "Select case must be a recv, send or timeout call.", token.Position, token.Line)
+			}
+			selectCase.Call = value[0].(*FuncCall)
+
+			token = parser.CurrentToken
+			if token.Type != "openbrace" {
+				throw(parser.CurrentFileName, EXCEPTION_ERROR, token.Position, token.Line, "openbrace", token.Type)
+			}
+			selectCase.Body = parser.ParseBody()
+
+			selectStmt.Cases = append(selectStmt.Cases, selectCase)
+		case "closebrace":
+			parser.Next()
+			break SELECTPAR
+		default:
+			throw(parser.CurrentFileName, INVALID_TOKEN_ERROR, token.Position, token.Line, token.Type)
+		}
+	}
+
+	return selectStmt
+}
+
 func (parser *Parser) ParseValue() []Node {
 	value := []Node{}
 	nextExpects := []string{}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+)
+
+var (
+	pathFuncs = map[string]func(v ...any) []any{
+		"path_join": func(v ...any) []any {
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			elements := make([]string, len(v))
+			for i, element := range v {
+				str, ok := element.(string)
+				if !ok {
+					inter.throw("Invalid argument #%d. Expected %s.", x, y, i+1, "string")
+				}
+				elements[i] = str
+			}
+
+			return []any{filepath.Join(elements...)}
+		},
+
+		"path_dir": func(v ...any) []any {
+			argsCheck(v, 1, 1, "string")
+
+			return []any{filepath.Dir(v[BUILTIN_SPECIALS].(string))}
+		},
+
+		"path_base": func(v ...any) []any {
+			argsCheck(v, 1, 1, "string")
+
+			return []any{filepath.Base(v[BUILTIN_SPECIALS].(string))}
+		},
+
+		"path_ext": func(v ...any) []any {
+			argsCheck(v, 1, 1, "string")
+
+			return []any{filepath.Ext(v[BUILTIN_SPECIALS].(string))}
+		},
+
+		"path_abs": func(v ...any) []any {
+			argsCheck(v, 1, 1, "string")
+
+			abs, err := filepath.Abs(v[BUILTIN_SPECIALS].(string))
+
+			return []any{abs, err}
+		},
+
+		"path_rel": func(v ...any) []any {
+			argsCheck(v, 2, 2, "string", "string")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			rel, err := filepath.Rel(v[0].(string), v[1].(string))
+
+			return []any{rel, err}
+		},
+
+		"path_clean": func(v ...any) []any {
+			argsCheck(v, 1, 1, "string")
+
+			return []any{filepath.Clean(v[BUILTIN_SPECIALS].(string))}
+		},
+
+		"path_split": func(v ...any) []any {
+			argsCheck(v, 1, 1, "string")
+
+			dir, file := filepath.Split(v[BUILTIN_SPECIALS].(string))
+
+			return []any{dir, file}
+		},
+
+		"path_isabs": func(v ...any) []any {
+			argsCheck(v, 1, 1, "string")
+
+			return []any{filepath.IsAbs(v[BUILTIN_SPECIALS].(string))}
+		},
+
+		"path_home": func(v ...any) []any {
+			home, err := os.UserHomeDir()
+
+			return []any{home, err}
+		},
+
+		"path_tempdir": func(v ...any) []any {
+			return []any{os.TempDir()}
+		},
+
+		"path_separators": func(v ...any) []any {
+			return []any{string(filepath.Separator), string(filepath.ListSeparator)}
+		},
+	}
+)
+
+func init() {
+	maps.Copy(builtinFuncs, pathFuncs)
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"maps"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+)
+
+// Process started by spawnProcess, Cancel releases its timeout
+type Process struct {
+	Cmd    *exec.Cmd
+	Ctx    context.Context
+	Cancel context.CancelFunc
+}
+
+var (
+	processes      = make(map[int64]*Process)
+	processesMutex sync.Mutex
+)
+
+var (
+	processFuncs = map[string]func(v ...any) []any{
+		"exec": func(v ...any) []any {
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+			if len(v) <= 0 {
+				inter.throw("Function requires one or more arguments.", x, y)
+			}
+
+			args := make([]string, len(v))
+			for i, arg := range v {
+				str, ok := arg.(string)
+				if !ok {
+					inter.throw("Invalid argument #%d. Expected %s.", x, y, i+1, "string")
+				}
+				args[i] = str
+			}
+
+			return runCommand(context.Background(), exec.Command(args[0], args[1:]...))
+		},
+
+		"process_run": func(v ...any) []any {
+			argsCheck(v, 3, 3, "string", "table", "any")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			ctx, cancel, cmd := inter.newCommand(v[BUILTIN_SPECIALS:], x, y)
+			defer cancel()
+
+			return runCommand(ctx, cmd)
+		},
+
+		"process_spawn": func(v ...any) []any {
+			argsCheck(v, 3, 3, "string", "table", "any")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			ctx, cancel, cmd := inter.newCommand(v[BUILTIN_SPECIALS:], x, y)
+
+			stdinPipe, err := cmd.StdinPipe()
+			if err != nil {
+				cancel()
+				return []any{int64(-1), int64(-1), int64(-1), int64(-1), err}
+			}
+
+			//Output goes through OS pipes, so it can be read even after wait
+			outputs := make([]*os.File, 2)
+			for i := range outputs {
+				reader, writer, err := os.Pipe()
+				if err != nil {
+					cancel()
+					return []any{int64(-1), int64(-1), int64(-1), int64(-1), err}
+				}
+				defer writer.Close()
+
+				outputs[i] = reader
+				if i == 0 {
+					cmd.Stdout = writer
+				} else {
+					cmd.Stderr = writer
+				}
+			}
+
+			if err := cmd.Start(); err != nil {
+				cancel()
+				return []any{int64(-1), int64(-1), int64(-1), int64(-1), err}
+			}
+
+			pid := int64(cmd.Process.Pid)
+
+			processesMutex.Lock()
+			processes[pid] = &Process{cmd, ctx, cancel}
+			processesMutex.Unlock()
+
+			stdinFd := registerStream(&Stream{Writer: stdinPipe, Closer: stdinPipe})
+			stdoutFd := registerStream(&Stream{Reader: bufio.NewReader(outputs[0]), Closer: outputs[0]})
+			stderrFd := registerStream(&Stream{Reader: bufio.NewReader(outputs[1]), Closer: outputs[1]})
+
+			return []any{pid, stdinFd, stdoutFd, stderrFd, nil}
+		},
+
+		"process_wait": func(v ...any) []any {
+			argsCheck(v, 1, 1, "int")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			process := inter.process(v[0], x, y)
+			defer process.Cancel()
+
+			code, err := exitStatus(process.Ctx, process.Cmd.Wait())
+
+			processesMutex.Lock()
+			delete(processes, toInt64(v[0]))
+			processesMutex.Unlock()
+
+			return []any{code, err}
+		},
+
+		"process_kill": func(v ...any) []any {
+			argsCheck(v, 2, 2, "int", "usint")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			process := inter.process(v[0], x, y)
+
+			return []any{process.Cmd.Process.Signal(syscall.Signal(toInt64(v[1])))}
+		},
+	}
+)
+
+func init() {
+	maps.Copy(builtinFuncs, processFuncs)
+}
+
+// Builds the command from name, args table and options table with dir, env and timeout keys
+func (inter *Interpreter) newCommand(v []any, x, y int) (context.Context, context.CancelFunc, *exec.Cmd) {
+	args := make([]string, 0, v[1].(*Map).Len())
+	for _, cell := range v[1].(*Map).AllFromFront() {
+		arg, ok := cell.Get().(string)
+		if !ok {
+			inter.throw("Process arguments must be strings.", x, y)
+		}
+		args = append(args, arg)
+	}
+
+	options, ok := v[2].(*Map)
+	if !ok && v[2] != nil {
+		inter.throw("Process options must be a table.", x, y)
+	}
+	option := func(key string) any {
+		if options == nil {
+			return nil
+		}
+
+		cell, _ := options.Get(key)
+		if cell == nil {
+			return nil
+		}
+
+		return cell.Get()
+	}
+
+	ctx, cancel := context.Background(), context.CancelFunc(func() {})
+	if timeout := option("timeout"); timeout != nil {
+		ctx, cancel = context.WithTimeout(ctx, inter.toDuration(timeout, x, y))
+	}
+
+	cmd := exec.CommandContext(ctx, v[0].(string), args...)
+
+	if dir := option("dir"); dir != nil {
+		cmd.Dir = format(dir)
+	}
+
+	if env := option("env"); env != nil {
+		envTable, ok := env.(*Map)
+		if !ok {
+			inter.throw("Process environment must be a table.", x, y)
+		}
+
+		//Variables of the table are added to the environment of the interpreter
+		cmd.Env = os.Environ()
+		for name, cell := range envTable.AllFromFront() {
+			cmd.Env = append(cmd.Env, format(name)+"="+format(cell.Get()))
+		}
+	}
+
+	return ctx, cancel, cmd
+}
+
+func runCommand(ctx context.Context, cmd *exec.Cmd) []any {
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+
+	code, err := exitStatus(ctx, cmd.Run())
+
+	return []any{stdout.String(), stderr.String(), code, err}
+}
+
+// Non-zero exit code isn't an error, failure to start or timeout is
+func exitStatus(ctx context.Context, err error) (int64, error) {
+	var exitErr *exec.ExitError
+
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		return -1, errors.New("process timed out")
+	case err == nil:
+		return 0, nil
+	case errors.As(err, &exitErr):
+		return int64(exitErr.ExitCode()), nil
+	}
+
+	return -1, err
+}
+
+func (inter *Interpreter) process(pid any, x, y int) *Process {
+	processesMutex.Lock()
+	defer processesMutex.Unlock()
+
+	process, ok := processes[toInt64(pid)]
+	if !ok {
+		inter.throw("Attempt to use a finished or non-existing process.", x, y)
+	}
+
+	return process
+}
@@ -0,0 +1,364 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	manifestFile = "yks.mod"
+	lockFile     = "yks.lock"
+	vendorDir    = "vendor"
+)
+
+// Dependency of the project, source is a local path relative to the manifest or a git URL
+type Dependency struct {
+	Name, Source, Version string
+}
+
+// Parsed yks.mod file:
+//
+//	module name
+//	entry main.yks
+//	require name source version
+type Manifest struct {
+	Module, Entry string
+	Requires      []*Dependency
+
+	Dir string //Directory of the manifest
+}
+
+func ReadManifest(dir string) (*Manifest, error) {
+	file, err := os.Open(filepath.Join(dir, manifestFile))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	manifest := &Manifest{Dir: dir}
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "//") {
+			continue
+		}
+
+		switch {
+		case fields[0] == "module" && len(fields) == 2:
+			manifest.Module = fields[1]
+		case fields[0] == "entry" && len(fields) == 2:
+			manifest.Entry = fields[1]
+		case fields[0] == "require" && len(fields) == 4:
+			manifest.Requires = append(manifest.Requires, &Dependency{fields[1], fields[2], fields[3]})
+		default:
+			return nil, fmt.Errorf("%s:%d: invalid directive '%s'", filepath.Join(dir, manifestFile), line, scanner.Text())
+		}
+	}
+
+	return manifest, scanner.Err()
+}
+
+func (manifest *Manifest) Write() error {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "module %s\n", manifest.Module)
+	if manifest.Entry != "" {
+		fmt.Fprintf(&builder, "entry %s\n", manifest.Entry)
+	}
+	if len(manifest.Requires) > 0 {
+		builder.WriteString("\n")
+	}
+	for _, dep := range manifest.Requires {
+		fmt.Fprintf(&builder, "require %s %s %s\n", dep.Name, dep.Source, dep.Version)
+	}
+
+	return os.WriteFile(filepath.Join(manifest.Dir, manifestFile), []byte(builder.String()), 0644)
+}
+
+// Finds the manifest of the project that contains the directory
+func findManifest(dir string) (*Manifest, bool) {
+	for {
+		manifest, err := ReadManifest(dir)
+		if err == nil {
+			return manifest, true
+		} else if !os.IsNotExist(err) {
+			throwNoPos(err.Error())
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, false
+		}
+		dir = parent
+	}
+}
+
+// Entry file of the project in the working directory, relative to it
+func manifestEntry() (string, bool) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", false
+	}
+
+	manifest, ok := findManifest(wd)
+	if !ok || manifest.Entry == "" {
+		return "", false
+	}
+
+	entry, err := filepath.Rel(wd, filepath.Join(manifest.Dir, manifest.Entry))
+	if err != nil {
+		return "", false
+	}
+
+	return entry, true
+}
+
+// Hash of the file names and contents of the directory
+func hashDir(dir string) (string, error) {
+	hash := sha256.New()
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		rel, _ := filepath.Rel(dir, path)
+		fmt.Fprintf(hash, "%x  %s\n", sha256.Sum256(content), filepath.ToSlash(rel))
+
+		return nil
+	})
+
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), err
+}
+
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, _ := filepath.Rel(src, path)
+		if entry.IsDir() && (entry.Name() == ".git" || rel == vendorDir) {
+			return filepath.SkipDir
+		}
+
+		target := filepath.Join(dst, rel)
+		if entry.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+
+		from, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer from.Close()
+
+		to, err := os.Create(target)
+		if err != nil {
+			return err
+		}
+		defer to.Close()
+
+		_, err = io.Copy(to, from)
+		return err
+	})
+}
+
+// Directory of the local dependency, other sources are git URLs
+func (dep *Dependency) LocalPath(baseDir string) (string, bool) {
+	if filepath.IsAbs(dep.Source) {
+		return dep.Source, true
+	} else if strings.HasPrefix(dep.Source, ".") {
+		return filepath.Join(baseDir, dep.Source), true
+	}
+
+	return "", false
+}
+
+// Copies a local dependency or clones the version tag of a git one
+func (dep *Dependency) Fetch(baseDir, dst string) error {
+	if src, ok := dep.LocalPath(baseDir); ok {
+		return copyDir(src, dst)
+	}
+
+	output, err := exec.Command("git", "clone", "--quiet", "--depth", "1", "--branch", dep.Version, dep.Source, dst).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git clone of '%s' failed: %s", dep.Source, strings.TrimSpace(string(output)))
+	}
+
+	return os.RemoveAll(filepath.Join(dst, ".git"))
+}
+
+// Fetches dependencies and their own dependencies into the flat vendor directory
+func (manifest *Manifest) vendor(target string, vendored map[string]*Dependency) {
+	for _, dep := range manifest.Requires {
+		if previous, ok := vendored[dep.Name]; ok {
+			if previous.Version != dep.Version {
+				throwNoPos("Dependency '%s' is required with different versions: %s and %s.", dep.Name, previous.Version, dep.Version)
+			}
+			continue
+		}
+
+		dst := filepath.Join(target, dep.Name)
+		if err := dep.Fetch(manifest.Dir, dst); err != nil {
+			throwNoPos("Unable to vendor '%s': %s.", dep.Name, err.Error())
+		}
+		vendored[dep.Name] = dep
+
+		//Local paths in the manifest of a local dependency are relative to its original directory
+		depDir, ok := dep.LocalPath(manifest.Dir)
+		if !ok {
+			depDir = dst
+		}
+
+		depManifest, err := ReadManifest(depDir)
+		if err == nil {
+			depManifest.vendor(target, vendored)
+		} else if !os.IsNotExist(err) {
+			throwNoPos(err.Error())
+		}
+	}
+}
+
+func modInit(args []string) {
+	wd, err := os.Getwd()
+	handle(err)
+
+	if _, err := os.Stat(filepath.Join(wd, manifestFile)); err == nil {
+		throwNoPos("%s already exists.", manifestFile)
+	}
+
+	manifest := &Manifest{
+		Module: filepath.Base(wd),
+		Entry:  "main" + fileType,
+		Dir:    wd,
+	}
+	if len(args) > 0 {
+		manifest.Module = args[0]
+	}
+
+	if err := manifest.Write(); err != nil {
+		throwNoPos(err.Error())
+	}
+	fmt.Printf("Created %s for module '%s'.\n", manifestFile, manifest.Module)
+}
+
+func modVendor([]string) {
+	manifest := mustFindManifest()
+
+	target := filepath.Join(manifest.Dir, vendorDir)
+	if err := os.RemoveAll(target); err != nil {
+		throwNoPos(err.Error())
+	}
+
+	vendored := make(map[string]*Dependency)
+	manifest.vendor(target, vendored)
+
+	names := slices.Sorted(maps.Keys(vendored))
+
+	var lock strings.Builder
+	for _, name := range names {
+		hash, err := hashDir(filepath.Join(target, name))
+		if err != nil {
+			throwNoPos(err.Error())
+		}
+
+		fmt.Fprintf(&lock, "%s %s %s\n", name, vendored[name].Version, hash)
+	}
+
+	if err := os.WriteFile(filepath.Join(manifest.Dir, lockFile), []byte(lock.String()), 0644); err != nil {
+		throwNoPos(err.Error())
+	}
+	fmt.Printf("Vendored %d dependencies.\n", len(names))
+}
+
+func modVerify([]string) {
+	manifest := mustFindManifest()
+
+	content, err := os.ReadFile(filepath.Join(manifest.Dir, lockFile))
+	if err != nil {
+		throwNoPos("Unable to read %s, run 'yks mod vendor' first.", lockFile)
+	}
+
+	//Versions of the locked dependencies by their names
+	locked := make(map[string]string)
+	failed := false
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+		name, hash := fields[0], fields[2]
+		locked[name] = fields[1]
+
+		actual, err := hashDir(filepath.Join(manifest.Dir, vendorDir, name))
+		switch {
+		case err != nil:
+			fmt.Printf("%s: missing from %s\n", name, vendorDir)
+			failed = true
+		case actual != hash:
+			fmt.Printf("%s: hash mismatch, expected %s got %s\n", name, hash, actual)
+			failed = true
+		}
+	}
+
+	for _, dep := range manifest.Requires {
+		version, ok := locked[dep.Name]
+		switch {
+		case !ok:
+			fmt.Printf("%s: missing from %s\n", dep.Name, lockFile)
+			failed = true
+		case version != dep.Version:
+			fmt.Printf("%s: version mismatch, %s requires %s but %s has %s\n", dep.Name, manifestFile, dep.Version, lockFile, version)
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+	fmt.Println("All dependencies verified.")
+}
+
+func mustFindManifest() *Manifest {
+	wd, err := os.Getwd()
+	handle(err)
+
+	manifest, ok := findManifest(wd)
+	if !ok {
+		throwNoPos("Unable to find %s, run 'yks mod init' first.", manifestFile)
+	}
+
+	return manifest
+}
+
+// yks mod init|vendor|verify
+func mod(args []string) {
+	subcommands := map[string]func([]string){
+		"init":   modInit,
+		"vendor": modVendor,
+		"verify": modVerify,
+	}
+
+	if len(args) == 0 || subcommands[args[0]] == nil {
+		throwNoPos("Usage: %s mod init [name] | vendor | verify", shortennedPLName)
+	}
+
+	subcommands[args[0]](args[1:])
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"maps"
+	"regexp"
+	"sync"
+)
+
+var (
+	//Compiled patterns are shared by all instances with the same pattern
+	regexCache      = make(map[string]*regexp.Regexp)
+	regexCacheMutex sync.Mutex
+)
+
+var (
+	regexFuncs = map[string]func(v ...any) []any{
+		"regex_compile": func(v ...any) []any {
+			argsCheck(v, 1, 1, "string")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			_, err := compileRegex(v[0].(string))
+
+			return []any{err}
+		},
+
+		"regex_match": func(v ...any) []any {
+			argsCheck(v, 2, 2, "string", "string")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			return []any{inter.regex(v[0], x, y).MatchString(v[1].(string))}
+		},
+
+		"regex_find": func(v ...any) []any {
+			argsCheck(v, 2, 2, "string", "string")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			loc := inter.regex(v[0], x, y).FindStringIndex(v[1].(string))
+			if loc == nil {
+				return []any{"", false}
+			}
+
+			return []any{v[1].(string)[loc[0]:loc[1]], true}
+		},
+
+		"regex_findall": func(v ...any) []any {
+			argsCheck(v, 3, 3, "string", "string", "int")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			matches := inter.regex(v[0], x, y).FindAllString(v[1].(string), int(toInt64(v[2])))
+
+			return []any{inter.stringsTable(matches, x, y)}
+		},
+
+		"regex_findsubmatch": func(v ...any) []any {
+			argsCheck(v, 2, 2, "string", "string")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			re := inter.regex(v[0], x, y)
+
+			groups := re.FindStringSubmatch(v[1].(string))
+			if groups == nil {
+				return []any{newMap("string", 0), false}
+			}
+
+			//Groups by their index, then named groups by their names
+			table := newMap("string", len(groups))
+			for i, group := range groups {
+				table.Set(int64(i), CLPTR(inter.CurrentScope, table.DataType, group, x, y))
+			}
+			for i, name := range re.SubexpNames() {
+				if name != "" {
+					table.Set(name, CLPTR(inter.CurrentScope, table.DataType, groups[i], x, y))
+				}
+			}
+			table.ToMemory()
+
+			return []any{table, true}
+		},
+
+		"regex_replace": func(v ...any) []any {
+			argsCheck(v, 3, 3, "string", "string", "any")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			re := inter.regex(v[0], x, y)
+
+			switch replacement := v[2].(type) {
+			case string:
+				return []any{re.ReplaceAllString(v[1].(string), replacement)}
+			case *FuncDec:
+				//Callback gets the matched text and returns its replacement
+				return []any{re.ReplaceAllStringFunc(v[1].(string), func(match string) string {
+					replaced, ok := firstValue(inter.callback(replacement, x, y, match)).(string)
+					if !ok {
+						inter.throw("Replace function must return a string value.", x, y)
+					}
+
+					return replaced
+				})}
+			default:
+				inter.throw("Invalid argument #%d. Expected %s.", x, y, 3, "string or func")
+			}
+
+			return nil
+		},
+
+		"regex_split": func(v ...any) []any {
+			argsCheck(v, 3, 3, "string", "string", "int")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			parts := inter.regex(v[0], x, y).Split(v[1].(string), int(toInt64(v[2])))
+
+			return []any{inter.stringsTable(parts, x, y)}
+		},
+	}
+)
+
+func init() {
+	maps.Copy(builtinFuncs, regexFuncs)
+}
+
+func compileRegex(pattern string) (*regexp.Regexp, error) {
+	regexCacheMutex.Lock()
+	defer regexCacheMutex.Unlock()
+
+	if re, ok := regexCache[pattern]; ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	regexCache[pattern] = re
+
+	return re, nil
+}
+
+func (inter *Interpreter) regex(pattern any, x, y int) *regexp.Regexp {
+	re, err := compileRegex(pattern.(string))
+	if err != nil {
+		inter.throw("Invalid regular expression: %s.", x, y, err.Error())
+	}
+
+	return re
+}
+
+func (inter *Interpreter) stringsTable(values []string, x, y int) *Map {
+	table := newMap("string", len(values))
+	for i, value := range values {
+		table.Set(int64(i), CLPTR(inter.CurrentScope, table.DataType, value, x, y))
+	}
+	table.ToMemory()
+
+	return table
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"maps"
+	"sync"
+	"unsafe"
+)
+
+var (
+	syncFuncs = map[string]func(v ...any) []any{
+		"mutex": func(v ...any) []any {
+			argsCheck(v, 0, 0)
+
+			return []any{&sync.Mutex{}}
+		},
+
+		"lock": func(v ...any) []any {
+			argsCheck(v, 1, 1, "mutex")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			v[0].(*sync.Mutex).Lock()
+
+			return nil
+		},
+
+		"unlock": func(v ...any) []any {
+			argsCheck(v, 1, 1, "mutex")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			mutex := v[0].(*sync.Mutex)
+			if mutex.TryLock() {
+				mutex.Unlock()
+				inter.throw("Attempt to unlock an unlocked mutex.", x, y)
+			}
+			mutex.Unlock()
+
+			return nil
+		},
+
+		"waitgroup": func(v ...any) []any {
+			argsCheck(v, 0, 0)
+
+			return []any{&sync.WaitGroup{}}
+		},
+
+		"wg_add": func(v ...any) []any {
+			argsCheck(v, 2, 2, "waitgroup", "int")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			defer func() {
+				if recover() != nil {
+					inter.throw("Wait group counter cannot be negative.", x, y)
+				}
+			}()
+			v[0].(*sync.WaitGroup).Add(int(toInt64(v[1])))
+
+			return nil
+		},
+
+		"wg_done": func(v ...any) []any {
+			argsCheck(v, 1, 1, "waitgroup")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			defer func() {
+				if recover() != nil {
+					inter.throw("Wait group counter cannot be negative.", x, y)
+				}
+			}()
+			v[0].(*sync.WaitGroup).Done()
+
+			return nil
+		},
+
+		"wg_wait": func(v ...any) []any {
+			argsCheck(v, 1, 1, "waitgroup")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			v[0].(*sync.WaitGroup).Wait()
+
+			return nil
+		},
+
+		"atomic_load": func(v ...any) []any {
+			argsCheck(v, 1, 1, "any")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			cell := inter.atomicCell(v[0], x, y)
+
+			return []any{cell.Load()}
+		},
+
+		"atomic_store": func(v ...any) []any {
+			argsCheck(v, 2, 2, "any", "int")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			cell := inter.atomicCell(v[0], x, y)
+
+			cell.mutex.Lock()
+			defer cell.mutex.Unlock()
+
+			value, _ := assertType(v[1], cell.DataType)
+			cell.Set(value, false, x, y)
+
+			return nil
+		},
+
+		"atomic_add": func(v ...any) []any {
+			argsCheck(v, 2, 2, "any", "int")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			cell := inter.atomicCell(v[0], x, y)
+
+			cell.mutex.Lock()
+			defer cell.mutex.Unlock()
+
+			old := cell.Get()
+			bits := twoDigitStr(cell.DataType[1:])
+
+			var value any
+			if checkDataType("uint", old) {
+				value = toUint(toUint64(old)+toUint64(v[1]), bits)
+			} else {
+				value = toInt(toInt64(old)+toInt64(v[1]), -bits)
+			}
+			cell.Set(value, false, x, y)
+
+			return []any{value}
+		},
+
+		"atomic_cas": func(v ...any) []any {
+			argsCheck(v, 3, 3, "any", "int", "int")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			cell := inter.atomicCell(v[0], x, y)
+
+			cell.mutex.Lock()
+			defer cell.mutex.Unlock()
+
+			old, _ := assertType(v[1], cell.DataType)
+			if cell.Get() != old {
+				return []any{false}
+			}
+
+			value, _ := assertType(v[2], cell.DataType)
+			cell.Set(value, false, x, y)
+
+			return []any{true}
+		},
+	}
+)
+
+func init() {
+	maps.Copy(builtinFuncs, syncFuncs)
+}
+
+// Finds the integer cell behind the pointer given to atomic builtins
+func (inter *Interpreter) atomicCell(ptr any, x, y int) *Cell {
+	address, ok := ptr.(uintptr)
+	if !ok {
+		inter.throw("Atomic operations require a pointer of the value.", x, y)
+	}
+
+	//Converted without unsafe.Pointer(uintptr) to pass checkptr of race builds
+	cell := inter.CurrentScope.GetCellWithAddress(*(*unsafe.Pointer)(unsafe.Pointer(&address)))
+	if cell == nil {
+		inter.throw("Attempt to do atomic operation on non-existing value.", x, y)
+	}
+
+	if !checkDataType("int", cell.Load()) {
+		inter.throw("Atomic operations are supported only on integer values.", x, y)
+	}
+
+	return cell
+}
+
+// Completes the body while holding the mutex
+func (inter *Interpreter) With(node *WithNode) (end, skip bool, value []any) {
+	mutex, ok := inter.GetNodeValueS(node.Mutex, node.X, node.Y).(*sync.Mutex)
+	if !ok {
+		inter.throw("With block requires a mutex value.", node.X, node.Y)
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	return inter.CompleteBody(node.Body, false, false)
+}
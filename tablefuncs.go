@@ -0,0 +1,624 @@
+package main
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"weak"
+
+	"github.com/elliotchance/orderedmap/v3"
+)
+
+var (
+	tableFuncs = map[string]func(v ...any) []any{
+		"table_sort": func(v ...any) []any {
+			argsCheck(v, 1, 2, "table")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			table := v[0].(*Map)
+			table.mutex.RLock()
+			cells := inter.sequenceCells(table, "sort", x, y)
+			table.mutex.RUnlock()
+
+			slices.SortFunc(cells, inter.tableComparator(v[1:], x, y))
+			table.SetSequence(cells)
+
+			return nil
+		},
+
+		"table_stablesort": func(v ...any) []any {
+			argsCheck(v, 1, 2, "table")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			table := v[0].(*Map)
+			table.mutex.RLock()
+			cells := inter.sequenceCells(table, "sort", x, y)
+			table.mutex.RUnlock()
+
+			slices.SortStableFunc(cells, inter.tableComparator(v[1:], x, y))
+			table.SetSequence(cells)
+
+			return nil
+		},
+
+		"table_reverse": func(v ...any) []any {
+			argsCheck(v, 1, 1, "table")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			table := v[0].(*Map)
+
+			table.mutex.Lock()
+			defer table.mutex.Unlock()
+
+			cells := inter.sequenceCells(table, "reverse", x, y)
+
+			slices.Reverse(cells)
+			table.setSequence(cells)
+
+			return nil
+		},
+
+		"table_keys": func(v ...any) []any {
+			argsCheck(v, 1, 1, "table")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			tableKeys, _ := tableEntries(v[0].(*Map))
+
+			keys := newMap("any", len(tableKeys))
+			for i, key := range tableKeys {
+				keys.Set(int64(i), CLPTR(inter.CurrentScope, keys.DataType, key, x, y))
+			}
+			keys.ToMemory()
+
+			return []any{keys}
+		},
+
+		"table_values": func(v ...any) []any {
+			argsCheck(v, 1, 1, "table")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			table := v[0].(*Map)
+			cells := tableCells(table)
+
+			values := newMap(table.DataType, len(cells))
+			for i, cell := range cells {
+				values.Set(int64(i), CLPTR(inter.CurrentScope, values.DataType, cell.Get(), x, y))
+			}
+			values.ToMemory()
+
+			return []any{values}
+		},
+
+		"table_map": func(v ...any) []any {
+			argsCheck(v, 2, 2, "table", "func")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			table := v[0].(*Map)
+			function := v[1].(*FuncDec)
+
+			keys, cells := tableEntries(table)
+
+			mapped := newMap("any", len(keys))
+			for i, key := range keys {
+				value := firstValue(inter.callback(function, x, y, cells[i].Get(), key))
+
+				mapped.Set(key, CLPTR(inter.CurrentScope, mapped.DataType, value, x, y))
+			}
+			mapped.ToMemory()
+
+			return []any{mapped}
+		},
+
+		"table_filter": func(v ...any) []any {
+			argsCheck(v, 2, 2, "table", "func")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			table := v[0].(*Map)
+			function := v[1].(*FuncDec)
+
+			sequence := table.IsSequence()
+			keys, cells := tableEntries(table)
+
+			filtered := newMap(table.DataType, 0)
+			for i, key := range keys {
+				value := cells[i].Get()
+
+				keep, ok := firstValue(inter.callback(function, x, y, value, key)).(bool)
+				if !ok {
+					inter.throw("Filter function must return a bool value.", x, y)
+				}
+				if !keep {
+					continue
+				}
+
+				if sequence {
+					key = int64(filtered.Len())
+				}
+				filtered.Set(key, CLPTR(inter.CurrentScope, filtered.DataType, value, x, y))
+			}
+			filtered.ToMemory()
+
+			return []any{filtered}
+		},
+
+		"table_reduce": func(v ...any) []any {
+			argsCheck(v, 3, 3, "table", "func", "any")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			table := v[0].(*Map)
+			function := v[1].(*FuncDec)
+			accumulator := v[2]
+
+			keys, cells := tableEntries(table)
+			for i, key := range keys {
+				accumulator = firstValue(inter.callback(function, x, y, accumulator, cells[i].Get(), key))
+			}
+
+			return []any{accumulator}
+		},
+
+		"table_insert": func(v ...any) []any {
+			argsCheck(v, 3, 3, "table", "int", "any")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			table := v[0].(*Map)
+			index := toInt64(v[1])
+			cell := CLPTR(inter.CurrentScope, table.DataType, v[2], x, y)
+
+			table.mutex.Lock()
+			defer table.mutex.Unlock()
+
+			cells := inter.sequenceCells(table, "insert into", x, y)
+			if index < 0 || index > int64(len(cells)) {
+				inter.throw("Insert index %d is out of range [0, %d].", x, y, index, len(cells))
+			}
+
+			cells = slices.Insert(cells, int(index), cell)
+			table.setSequence(cells)
+
+			return nil
+		},
+
+		"table_remove": func(v ...any) []any {
+			argsCheck(v, 2, 2, "table", "int")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			table := v[0].(*Map)
+			index := toInt64(v[1])
+
+			table.mutex.Lock()
+			defer table.mutex.Unlock()
+
+			cells := inter.sequenceCells(table, "remove from", x, y)
+			if index < 0 || index >= int64(len(cells)) {
+				inter.throw("Remove index %d is out of range [0, %d).", x, y, index, len(cells))
+			}
+
+			removed := cells[index].Get()
+
+			cells = slices.Delete(cells, int(index), int(index)+1)
+			table.setSequence(cells)
+
+			return []any{removed}
+		},
+
+		"table_slice": func(v ...any) []any {
+			argsCheck(v, 3, 3, "table", "int", "int")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			table := v[0].(*Map)
+
+			//Slicing registers the slice in its base
+			table.mutex.Lock()
+			defer table.mutex.Unlock()
+
+			i, j := inter.SliceBounds(v[1], v[2], table.Len(), x, y)
+
+			return []any{table.Slice(inter.CurrentScope, i, j, x, y)}
+		},
+
+		"table_contains": func(v ...any) []any {
+			argsCheck(v, 2, 2, "table", "any")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			for _, cell := range tableCells(v[0].(*Map)) {
+				if valuesEqual(cell.Get(), v[1]) {
+					return []any{true}
+				}
+			}
+
+			return []any{false}
+		},
+
+		"table_indexof": func(v ...any) []any {
+			argsCheck(v, 2, 2, "table", "any")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			for i, cell := range tableCells(v[0].(*Map)) {
+				if valuesEqual(cell.Get(), v[1]) {
+					return []any{int64(i)}
+				}
+			}
+
+			return []any{int64(-1)}
+		},
+
+		"table_min": func(v ...any) []any {
+			argsCheck(v, 1, 2, "table")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			cells := tableCells(v[0].(*Map))
+			if len(cells) == 0 {
+				return []any{nil}
+			}
+
+			return []any{slices.MinFunc(cells, inter.tableComparator(v[1:], x, y)).Get()}
+		},
+
+		"table_max": func(v ...any) []any {
+			argsCheck(v, 1, 2, "table")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			cells := tableCells(v[0].(*Map))
+			if len(cells) == 0 {
+				return []any{nil}
+			}
+
+			return []any{slices.MaxFunc(cells, inter.tableComparator(v[1:], x, y)).Get()}
+		},
+
+		"table_append": func(v ...any) []any {
+			argsCheck(v, 2, 2, "table", "any")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			table := v[0].(*Map)
+			cell := CLPTR(inter.CurrentScope, table.DataType, v[1], x, y)
+
+			//Key is taken under the lock, so tasks appending to one table don't overwrite each other
+			table.mutex.Lock()
+			defer table.mutex.Unlock()
+
+			table.Set(table.nextIndex(), cell)
+			table.toMemory()
+
+			return nil
+		},
+
+		"compare": func(v ...any) []any {
+			argsCheck(v, 2, 2, "any", "any")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			order, ok := compareValues(v[0], v[1])
+			if !ok {
+				if !deepEqual(v[0], v[1], nil) {
+					inter.throw("Unable to compare values of types '%s' and '%s'.", x, y, getValueType(v[0]), getValueType(v[1]))
+				}
+				order = 0
+			}
+
+			return []any{int64(order)}
+		},
+	}
+)
+
+func init() {
+	maps.Copy(builtinFuncs, tableFuncs)
+}
+
+func newMap(dataType string, capacity int) *Map {
+	return &Map{
+		OrderedMap: orderedmap.NewOrderedMapWithCapacity[any, *Cell](capacity),
+
+		DataType: dataType,
+
+		Pointers: []any{},
+		Layout:   []string{},
+		Mem:      []byte{},
+		mutex:    &sync.RWMutex{},
+
+		nextKnown: true,
+	}
+}
+
+func tableCells(m *Map) []*Cell {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.cells()
+}
+
+// Snapshot of keys and elements, callbacks run over it without holding the lock of the table
+func tableEntries(m *Map) ([]any, []*Cell) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	keys := make([]any, 0, m.Len())
+	cells := make([]*Cell, 0, m.Len())
+	for key, cell := range m.AllFromFront() {
+		keys = append(keys, key)
+		cells = append(cells, cell)
+	}
+
+	return keys, cells
+}
+
+// Key after the highest integer key, so a table with holes left by delete doesn't lose elements, the caller holds the lock
+func (m *Map) nextIndex() int64 {
+	if !m.nextKnown {
+		m.next = 0
+		for key := range m.Keys() {
+			if k, ok := key.(int64); ok && k >= m.next {
+				m.next = k + 1
+			}
+		}
+		m.nextKnown = true
+	}
+
+	return m.next
+}
+
+// Sets the element and keeps the key for appending up to date
+func (m *Map) Set(key any, cell *Cell) bool {
+	if k, ok := key.(int64); ok && m.nextKnown && k >= m.next {
+		m.next = k + 1
+	}
+
+	return m.OrderedMap.Set(key, cell)
+}
+
+// Deleting the highest key makes the key for appending be found again
+func (m *Map) Delete(key any) bool {
+	if k, ok := key.(int64); ok && k == m.next-1 {
+		m.nextKnown = false
+	}
+
+	return m.OrderedMap.Delete(key)
+}
+
+// Elements for callers that hold the lock of the table
+func (m *Map) cells() []*Cell {
+	cells := make([]*Cell, 0, m.Len())
+	for _, cell := range m.AllFromFront() {
+		cells = append(cells, cell)
+	}
+
+	return cells
+}
+
+// Copies elements from i to j-1 into a new table, fixed size elements keep sharing the memory of the original table
+func (m *Map) Slice(scope *Scope, i, j int, x, y int) *Map {
+	sliced := newMap(m.DataType, j-i)
+
+	n := 0
+	for _, cell := range m.AllFromFront() {
+		if n >= j {
+			break
+		}
+		if n >= i {
+			sliced.Set(int64(n-i), CLPTR(scope, sliced.DataType, cell.Get(), x, y))
+		}
+		n++
+	}
+	sliced.ToMemory()
+
+	size := fixedSizeOf(m.DataType)
+	if size > 0 && len(m.Mem) >= j*size && slices.Equal(sliced.Mem, m.Mem[i*size:j*size]) {
+		base := m
+		if m.Base != nil {
+			base = m.Base
+		}
+		sliced.Mem = m.Mem[i*size : j*size : j*size]
+		sliced.Base = base
+		sliced.mutex = base.mutex
+		base.views = append(base.views, weak.Make(sliced))
+	}
+
+	return sliced
+}
+
+func fixedSizeOf(dataType string) int {
+	switch dataType {
+	case "i8", "u8", "bool":
+		return 1
+	case "i16", "u16":
+		return 2
+	case "i32", "u32", "f32":
+		return 4
+	case "i64", "u64", "f64", "pointer":
+		return 8
+	}
+
+	return 0
+}
+
+// Elements of the table for builtins that renumber them, other keys would be lost, so they are an error, the caller holds the lock
+func (inter *Interpreter) sequenceCells(m *Map, action string, x, y int) []*Cell {
+	if !m.isSequence() {
+		inter.throw("Attempt to %s a table with keys other than 0 to len-1.", x, y, action)
+	}
+
+	return m.cells()
+}
+
+// Replaces elements of the table with the cells keyed from 0 to len-1
+func (m *Map) SetSequence(cells []*Cell) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.setSequence(cells)
+}
+
+func (m *Map) setSequence(cells []*Cell) {
+	m.OrderedMap = orderedmap.NewOrderedMapWithCapacity[any, *Cell](len(cells))
+	m.next, m.nextKnown = 0, true
+	for i, cell := range cells {
+		m.Set(int64(i), cell)
+	}
+
+	m.toMemory()
+}
+
+// Checks if the keys of the table go from 0 to len-1 in order
+func (m *Map) IsSequence() bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.isSequence()
+}
+
+func (m *Map) isSequence() bool {
+	i := int64(0)
+	for key := range m.Keys() {
+		if k, ok := key.(int64); !ok || k != i {
+			return false
+		}
+		i++
+	}
+
+	return true
+}
+
+func firstValue(values []any) any {
+	if len(values) == 0 {
+		return nil
+	}
+
+	return values[0]
+}
+
+// Calls the callback with no more arguments than it declares, top-level functions are completed in their file even if called from another module
+func (inter *Interpreter) callback(function *FuncDec, x, y int, args ...any) []any {
+	if function.Template == nil && len(args) > len(function.Arguments) {
+		args = args[:len(function.Arguments)]
+	}
+
+	if function.Module == nil && function.Scope != nil {
+		current := inter.CurrentScope
+		inter.CurrentScope = function.Scope
+		defer func() { inter.CurrentScope = current }()
+	}
+
+	return inter.CallFunctionValue(function, x, y, args...)
+}
+
+func compareValues(a, b any) (int, bool) {
+	switch {
+	case checkDataType("uint", a) && checkDataType("uint", b):
+		return cmpOrdered(toUint64(a), toUint64(b)), true
+	case checkDataType("int", a) && checkDataType("int", b) && !checkDataType("uint", a) && !checkDataType("uint", b):
+		return cmpOrdered(toInt64(a), toInt64(b)), true
+	case checkDataType("number", a) && checkDataType("number", b):
+		return cmpOrdered(mustNTOF64(a), mustNTOF64(b)), true
+	case checkType[string](a) && checkType[string](b):
+		return strings.Compare(a.(string), b.(string)), true
+	case isBigNumber(a) && getValueType(a) == getValueType(b):
+		return bigCompare(a, b), true
+	}
+
+	return 0, false
+}
+
+func cmpOrdered[T int64 | uint64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+func valuesEqual(a, b any) bool {
+	return deepEqual(a, b, nil)
+}
+
+// Returns the ordering function for sorting builtins, comparator may return a bool(less) or an integer
+func (inter *Interpreter) tableComparator(comparator []any, x, y int) func(a, b *Cell) int {
+	if len(comparator) == 0 || comparator[0] == nil {
+		return func(a, b *Cell) int {
+			av, bv := a.Get(), b.Get()
+
+			order, ok := compareValues(av, bv)
+			if !ok {
+				inter.throw("Unable to compare values of types '%s' and '%s'.", x, y, getValueType(av), getValueType(bv))
+			}
+
+			return order
+		}
+	}
+
+	function, ok := comparator[0].(*FuncDec)
+	if !ok {
+		inter.throw("Comparator must be a function.", x, y)
+	}
+
+	return func(a, b *Cell) int {
+		switch result := firstValue(inter.callback(function, x, y, a.Get(), b.Get())).(type) {
+		case bool:
+			if result {
+				return -1
+			}
+			if less, _ := firstValue(inter.callback(function, x, y, b.Get(), a.Get())).(bool); less {
+				return 1
+			}
+			return 0
+		default:
+			if !checkDataType("int", result) {
+				inter.throw("Comparator must return a bool or an integer value.", x, y)
+			}
+			if checkDataType("uint", result) {
+				return cmpOrdered(toUint64(result), 0)
+			}
+			return cmpOrdered(toInt64(result), 0)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"maps"
+	"reflect"
+	"time"
+)
+
+// Function running concurrently, Results are available after Done is closed
+type Task struct {
+	Done    chan struct{}
+	Results []any
+}
+
+// Channel that accepts only values of DataType
+type Channel struct {
+	DataType string
+	C        chan any
+}
+
+var (
+	taskFuncs = map[string]func(v ...any) []any{
+		"chan": func(v ...any) []any {
+			argsCheck(v, 1, 2, "string", "int")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			capacity := int64(0)
+			if len(v) > 1 {
+				capacity = toInt64(v[1])
+			}
+			if capacity < 0 {
+				inter.throw("Channel capacity cannot be negative.", x, y)
+			}
+
+			return []any{&Channel{
+				DataType: v[0].(string),
+				C:        make(chan any, capacity),
+			}}
+		},
+
+		"send": func(v ...any) []any {
+			argsCheck(v, 2, 2, "chan", "any")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			channel := v[0].(*Channel)
+			value := inter.channelValue(channel, v[1], x, y)
+
+			defer func() {
+				if recover() != nil {
+					inter.throw("Attempt to send a value to a closed channel.", x, y)
+				}
+			}()
+			channel.C <- value
+
+			return nil
+		},
+
+		"recv": func(v ...any) []any {
+			argsCheck(v, 1, 1, "chan")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			value, ok := <-v[0].(*Channel).C
+
+			return []any{value, ok}
+		},
+
+		"close": func(v ...any) []any {
+			argsCheck(v, 1, 1, "chan")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			defer func() {
+				if recover() != nil {
+					inter.throw("Attempt to close already closed channel.", x, y)
+				}
+			}()
+			close(v[0].(*Channel).C)
+
+			return nil
+		},
+
+		"wait": func(v ...any) []any {
+			argsCheck(v, 1, 1, "task")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			task := v[0].(*Task)
+			<-task.Done
+
+			return task.Results
+		},
+	}
+)
+
+func init() {
+	maps.Copy(builtinFuncs, taskFuncs)
+}
+
+// Checks the value against the channel type, integer literals become values of that type
+func (inter *Interpreter) channelValue(channel *Channel, value any, x, y int) any {
+	return CLPTR(inter.CurrentScope, channel.DataType, value, x, y).Get()
+}
+
+// Evaluates the function and its arguments without calling it, used by spawn and defer
+func (inter *Interpreter) PrepareCall(nodes []Node, keyword string, x, y int) (*FuncCall, *FuncDec, []any) {
+	if len(nodes) != 1 || !checkType[*FuncCall](nodes[0]) {
+		inter.throw("%s keyword requires a function call.", x, y, keyword)
+	}
+	call := nodes[0].(*FuncCall)
+
+	funcDec, ok := inter.GetNodeValue(call.Func).(*FuncDec)
+	if !ok {
+		inter.throw("Attempt to call a non-function object.", x, y)
+	}
+
+	argsValues := make([][]Node, len(call.Arguments))
+	for i, argNode := range call.Arguments {
+		argsValues[i] = []Node{argNode}
+	}
+
+	args := inter.CookValues(uint(len(call.Arguments)), argsValues, call.X, call.Y)
+	if funcDec.Template != nil {
+		for i, arg := range args {
+			switch arg := arg.(type) {
+			case rawuint64:
+				args[i] = uint64(arg)
+			case rawint64:
+				args[i] = int64(arg)
+			}
+		}
+	}
+
+	return call, funcDec, args
+}
+
+// Runs the function call in a new goroutine with its own interpreter, arguments are evaluated at the spawn site
+func (inter *Interpreter) Spawn(node *SpawnNode) *Task {
+	call, funcDec, args := inter.PrepareCall(node.Call, "Spawn", node.X, node.Y)
+
+	taskInter := &Interpreter{
+		CurrentFileName: inter.CurrentFileName,
+		AST:             inter.AST,
+		UnableToImport:  true,
+		Checked:         inter.Checked,
+	}
+	//Declarations of the task go to its own scope, variables of the spawn site are reached through the synchronized parent
+	taskInter.CurrentScope = NewScope(taskInter, inter.CurrentScope)
+	taskInter.CallSite.Store(inter.CallSite.Load())
+	task := &Task{
+		Done: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(task.Done)
+
+		task.Results = taskInter.CallFunctionValue(funcDec, call.X, call.Y, args...)
+	}()
+
+	return task
+}
+
+// Waits for the first ready case of the select statement and completes its body
+func (inter *Interpreter) Select(node *SelectStmt) (end, skip bool, value []any) {
+	cases := make([]reflect.SelectCase, len(node.Cases))
+	channels := make([]*Channel, len(node.Cases))
+
+	for i, selectCase := range node.Cases {
+		call := selectCase.Call
+		x, y := call.X, call.Y
+
+		funcName, _ := call.Func.(*IdentNode)
+		if funcName == nil {
+			inter.throw("Select case must be a recv, send or timeout call.", x, y)
+		}
+
+		argsValues := make([][]Node, len(call.Arguments))
+		for i, argNode := range call.Arguments {
+			argsValues[i] = []Node{argNode}
+		}
+		args := inter.CookValues(uint(len(call.Arguments)), argsValues, x, y)
+
+		switch funcName.Value {
+		case "recv", "send":
+			argsCount := 1
+			if funcName.Value == "send" {
+				argsCount = 2
+			}
+			if len(args) != argsCount {
+				inter.throw("Function requires %d argument(s).", x, y, argsCount)
+			}
+
+			channel, ok := args[0].(*Channel)
+			if !ok {
+				inter.throw("Expected 'chan' got '%s'", x, y, getValueType(args[0]))
+			}
+			channels[i] = channel
+
+			cases[i] = reflect.SelectCase{
+				Dir:  reflect.SelectRecv,
+				Chan: reflect.ValueOf(channel.C),
+			}
+			if funcName.Value == "send" {
+				if len(selectCase.Idents) > 0 {
+					inter.throw("Send case doesn't have values to assign.", x, y)
+				}
+
+				sendValue := inter.channelValue(channel, args[1], x, y)
+
+				cases[i].Dir = reflect.SelectSend
+				cases[i].Send = reflect.ValueOf(&sendValue).Elem()
+			}
+		case "timeout":
+			if len(args) != 1 {
+				inter.throw("Function requires %d argument(s).", x, y, 1)
+			}
+
+			duration := inter.toDuration(args[0], x, y)
+			if len(selectCase.Idents) > 0 {
+				inter.throw("Timeout case doesn't have values to assign.", x, y)
+			}
+
+			cases[i] = reflect.SelectCase{
+				Dir:  reflect.SelectRecv,
+				Chan: reflect.ValueOf(time.After(duration)),
+			}
+		default:
+			inter.throw("Select case must be a recv, send or timeout call.", x, y)
+		}
+
+		if len(selectCase.Idents) > 2 {
+			inter.throw("Too many values(%d) for %d identifier(s).", x, y, 2, len(selectCase.Idents))
+		}
+	}
+
+	chosen, received, ok := inter.selectCases(node, cases)
+	selectCase := node.Cases[chosen]
+
+	addToScope := [][3]any{}
+	if len(selectCase.Idents) > 0 {
+		var value any
+		dataType := "any"
+		if ok {
+			value = received.Interface()
+			dataType = channels[chosen].DataType
+		}
+
+		addToScope = append(addToScope, [3]any{selectCase.Idents[0].Value, value, dataType})
+		if len(selectCase.Idents) > 1 {
+			addToScope = append(addToScope, [3]any{selectCase.Idents[1].Value, ok, "bool"})
+		}
+	}
+
+	return inter.CompleteBody(selectCase.Body, false, false, addToScope...)
+}
+
+func (inter *Interpreter) selectCases(node *SelectStmt, cases []reflect.SelectCase) (chosen int, received reflect.Value, ok bool) {
+	defer func() {
+		if recover() != nil {
+			inter.throw("Attempt to send a value to a closed channel.", node.X, node.Y)
+		}
+	}()
+
+	return reflect.Select(cases)
+}
+
+// Numbers are milliseconds whether they are integers or floats, Duration instances of the time module are exact
+func (inter *Interpreter) toDuration(value any, x, y int) time.Duration {
+	if instance, ok := value.(*StructObject); ok && instance != nil && instance.Identifier == "Duration" {
+		if ns, ok := instance.Get("ns"); ok {
+			return time.Duration(toInt64(ns))
+		}
+	}
+
+	switch {
+	case checkDataType("float", value):
+		return time.Duration(mustNTOF64(value) * float64(time.Millisecond))
+	case checkDataType("uint", value) || checkType[rawuint64](value):
+		return time.Duration(toUint64(value)) * time.Millisecond
+	case checkDataType("int", value):
+		return time.Duration(toInt64(value)) * time.Millisecond
+	}
+	inter.throw("Time value must be a number of milliseconds or a Duration.", x, y)
+
+	return 0
+}
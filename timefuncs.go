@@ -0,0 +1,213 @@
+package main
+
+import (
+	"maps"
+	"sync"
+	"time"
+	_ "time/tzdata" //Time zones work without the database of the operating system
+)
+
+var (
+	//Monotonic readings are nanoseconds since the interpreter started
+	monotonicStart = time.Now()
+
+	locations      = map[string]*time.Location{"Local": time.Local, "UTC": time.UTC}
+	locationsMutex sync.Mutex
+
+	durationUnits = map[string]time.Duration{
+		"ns": time.Nanosecond,
+		"us": time.Microsecond,
+		"ms": time.Millisecond,
+		"s":  time.Second,
+		"m":  time.Minute,
+		"h":  time.Hour,
+	}
+)
+
+var (
+	timeFuncs = map[string]func(v ...any) []any{
+		"time_now": func(v ...any) []any {
+			now := time.Now()
+
+			return []any{now.UnixNano(), "Local", int64(now.Sub(monotonicStart))}
+		},
+
+		"time_mono": func(v ...any) []any {
+			return []any{int64(time.Since(monotonicStart))}
+		},
+
+		"time_zone": func(v ...any) []any {
+			argsCheck(v, 1, 1, "string")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			_, err := loadLocation(v[0].(string))
+
+			return []any{err}
+		},
+
+		"time_format": func(v ...any) []any {
+			argsCheck(v, 3, 3, "int", "string", "string")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			return []any{inter.timeValue(v[0], v[1], x, y).Format(v[2].(string))}
+		},
+
+		"time_parse": func(v ...any) []any {
+			argsCheck(v, 3, 3, "string", "string", "string")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			location, err := loadLocation(v[2].(string))
+			if err != nil {
+				return []any{int64(0), err}
+			}
+
+			t, err := time.ParseInLocation(v[0].(string), v[1].(string), location)
+
+			return []any{t.UnixNano(), err}
+		},
+
+		"time_date": func(v ...any) []any {
+			argsCheck(v, 8, 8, "int", "int", "int", "int", "int", "int", "int", "string")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			location, err := loadLocation(v[7].(string))
+			if err != nil {
+				return []any{int64(0), err}
+			}
+
+			parts := make([]int, 7)
+			for i := range parts {
+				parts[i] = int(toInt64(v[i]))
+			}
+			t := time.Date(parts[0], time.Month(parts[1]), parts[2], parts[3], parts[4], parts[5], parts[6], location)
+
+			return []any{t.UnixNano(), nil}
+		},
+
+		"time_fields": func(v ...any) []any {
+			argsCheck(v, 2, 2, "int", "string")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			t := inter.timeValue(v[0], v[1], x, y)
+
+			return []any{
+				int64(t.Year()), int64(t.Month()), int64(t.Day()),
+				int64(t.Hour()), int64(t.Minute()), int64(t.Second()), int64(t.Nanosecond()),
+				int64(t.Weekday()), int64(t.YearDay()),
+			}
+		},
+
+		"time_adddate": func(v ...any) []any {
+			argsCheck(v, 5, 5, "int", "string", "int", "int", "int")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			t := inter.timeValue(v[0], v[1], x, y).AddDate(int(toInt64(v[2])), int(toInt64(v[3])), int(toInt64(v[4])))
+
+			return []any{t.UnixNano()}
+		},
+
+		"time_duration": func(v ...any) []any {
+			argsCheck(v, 2, 2, "number", "string")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			unit := inter.durationUnit(v[1].(string), x, y)
+			if checkDataType("int", v[0]) {
+				return []any{toInt64(v[0]) * int64(unit)}
+			}
+
+			return []any{int64(mustNTOF64(v[0]) * float64(unit))}
+		},
+
+		"time_durationin": func(v ...any) []any {
+			argsCheck(v, 2, 2, "int", "string")
+			x, y := v[0].(int), v[1].(int)
+			inter := v[2].(*Interpreter)
+
+			v = v[BUILTIN_SPECIALS:]
+
+			unit := inter.durationUnit(v[1].(string), x, y)
+
+			return []any{float64(toInt64(v[0])) / float64(unit)}
+		},
+
+		"time_parseduration": func(v ...any) []any {
+			argsCheck(v, 1, 1, "string")
+
+			v = v[BUILTIN_SPECIALS:]
+
+			d, err := time.ParseDuration(v[0].(string))
+
+			return []any{int64(d), err}
+		},
+
+		"time_formatduration": func(v ...any) []any {
+			argsCheck(v, 1, 1, "int")
+
+			return []any{time.Duration(toInt64(v[BUILTIN_SPECIALS])).String()}
+		},
+
+		"time_sleep": func(v ...any) []any {
+			argsCheck(v, 1, 1, "int")
+
+			time.Sleep(time.Duration(toInt64(v[BUILTIN_SPECIALS])))
+
+			return nil
+		},
+	}
+)
+
+func init() {
+	maps.Copy(builtinFuncs, timeFuncs)
+}
+
+// Loads the location from the embedded database once, names are like "UTC", "Local" or "Europe/Kyiv"
+func loadLocation(name string) (*time.Location, error) {
+	locationsMutex.Lock()
+	defer locationsMutex.Unlock()
+
+	if location, ok := locations[name]; ok {
+		return location, nil
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations[name] = location
+
+	return location, nil
+}
+
+// Time of nanoseconds since the Unix epoch in the zone
+func (inter *Interpreter) timeValue(ns, zone any, x, y int) time.Time {
+	location, err := loadLocation(zone.(string))
+	if err != nil {
+		inter.throw("Unknown time zone '%s'.", x, y, zone)
+	}
+
+	return time.Unix(0, toInt64(ns)).In(location)
+}
+
+func (inter *Interpreter) durationUnit(unit string, x, y int) time.Duration {
+	d, ok := durationUnits[unit]
+	if !ok {
+		inter.throw("Unknown duration unit '%s', expected one of ns, us, ms, s, m, h.", x, y, unit)
+	}
+
+	return d
+}
@@ -1,5 +1,10 @@
 package main
 
+import (
+	"math"
+	"math/big"
+)
+
 func assertType(v any, targetType string) (any, bool) {
 	switch targetType {
 	case "i64", "i32", "i16", "i8":
@@ -12,6 +17,53 @@ func assertType(v any, targetType string) (any, bool) {
 		return float32(mustNTOF64(v)), true
 	case "pointer":
 		return uintptr(toUint64(v)), true
+	case "bigint", "decimal":
+		return toBigNumber(v, targetType)
 	}
 	return v, false
 }
+
+// Checks if the number can be converted to the integer type without losing its value(fraction of floats is ignored)
+func fitsType(v any, targetType string) bool {
+	var bits int
+	switch targetType {
+	case "i64", "i32", "i16", "i8", "u64", "u32", "u16", "u8":
+		bits = twoDigitStr(targetType[1:])
+	default:
+		return true
+	}
+	signed := targetType[0] == 'i'
+
+	if d, ok := v.(*Decimal); ok {
+		v = d.Int()
+	}
+	if n, ok := v.(*big.Int); ok {
+		if signed {
+			return n.IsInt64() && fitsType(n.Int64(), targetType)
+		}
+		return n.IsUint64() && fitsType(n.Uint64(), targetType)
+	}
+
+	switch {
+	case checkDataType("uint", v) || checkType[rawuint64](v):
+		n := toUint64(v)
+		if signed {
+			return n <= math.MaxInt64>>(64-bits)
+		}
+		return bits == 64 || n < 1<<bits
+	case checkDataType("int", v):
+		n := toInt64(v)
+		if !signed {
+			return n >= 0 && (bits == 64 || n < 1<<bits)
+		}
+		return bits == 64 || n >= -(1<<(bits-1)) && n < 1<<(bits-1)
+	case checkDataType("float", v):
+		f := math.Trunc(mustNTOF64(v))
+		if !signed {
+			return f >= 0 && f < math.Ldexp(1, bits)
+		}
+		return f >= -math.Ldexp(1, bits-1) && f < math.Ldexp(1, bits-1)
+	}
+
+	return true
+}